@@ -11,8 +11,11 @@ import (
 	"context"
 	"errors"
 	"net/http"
+	"net/url"
 	"path/filepath"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/desertbit/closer/v3"
@@ -22,12 +25,26 @@ import (
 const (
 	mimeJSON = "application/json"
 	mimeURL  = "application/x-www-form-urlencoded"
+
+	defaultRequestIDHeader = "X-Request-ID"
+	defaultTimeout         = 30 * time.Second
+	defaultTokenTTL        = time.Hour
+
+	// The query parameter that carries the API token, see Options.AuthViaQueryParam.
+	authTokenParam = "authToken"
+
+	// Wekan serves these routes outside of the "/api" path, see
+	// https://wekan.github.io/api/v5.13/#wekan-rest-api-login
+	defaultLoginPath    = "/users/login"
+	defaultRegisterPath = "/users/register"
 )
 
 type Options struct {
 	// Mandataroy fields.
 
 	// The address of the wekan server the client should connect to.
+	// It must be the bare address of the server, e.g. "https://wekan.example.com",
+	// the "/api" path is added by the client. A trailing "/api" is removed.
 	RemoteAddr string
 	// The username of the user that should be used to log in.
 	Username string
@@ -40,6 +57,25 @@ type Options struct {
 	// If nil, a default client is used.
 	Client *http.Client
 
+	// The maximum number of idle connections the default client keeps to the server.
+	// Ignored, if Client is set.
+	// Defaults to MaxConcurrency, so that the connections of concurrent requests
+	// are reused instead of being closed after each batch.
+	MaxIdleConnsPerHost int
+
+	// The maximum number of connections the default client opens to the server,
+	// including those in use. Further requests wait for a free connection.
+	// Ignored, if Client is set.
+	// If 0, the number of connections is not limited.
+	// Note: When set lower than MaxConcurrency, concurrent requests are throttled to it.
+	MaxConnsPerHost int
+
+	// If true, responses are requested uncompressed.
+	// By default, the http.Transport requests gzip compressed responses and decompresses
+	// them transparently, which speeds up large responses like board exports on slow links.
+	// Disabling it is mainly useful for debugging, e.g. to inspect the traffic.
+	DisableCompression bool
+
 	// The time the client waits between login attempts.
 	// Can not be shorter than 1 second.
 	TimeBetweenLoginAttemps time.Duration
@@ -47,6 +83,117 @@ type Options struct {
 	// The closer used to manage all routines of the client.
 	// If nil, a default closer is created.
 	Closer closer.Closer
+
+	// The context key under which a request ID is stored in the context
+	// passed to the client's methods.
+	// If set and the context holds a non-empty string value for it, the value
+	// is sent with every request in the RequestIDHeader header, so that the
+	// server's logs can be correlated with the caller's.
+	// Request IDs set with ContextWithRequestID are always sent and take precedence.
+	RequestIDContextKey any
+
+	// If true, a random request ID is sent with every request whose context
+	// does not carry one.
+	GenerateRequestIDs bool
+
+	// The header the request ID is sent in.
+	// Defaults to "X-Request-ID".
+	RequestIDHeader string
+
+	// The language the server should use for localized messages, e.g. "de".
+	// It is sent as Accept-Language header with every request.
+	// If empty, the server's default language is used.
+	Language string
+
+	// The maximum size of a response body the client reads.
+	// Larger responses fail with ErrResponseTooLarge.
+	// If 0, the size is not limited.
+	MaxResponseBytes int64
+
+	// The maximum number of requests that convenience methods operating on
+	// multiple items send concurrently.
+	// Defaults to 8.
+	MaxConcurrency int
+
+	// If true, mutating requests (POST, PUT, DELETE) are logged instead of sent
+	// and succeed with zero-valued responses, e.g. new resources have an empty id.
+	// GET requests and the login are performed as usual.
+	DryRun bool
+
+	// The timeout of a request, applied if the context passed to a method has no deadline.
+	// Defaults to 30 seconds.
+	// Note: The Timeout of a custom Client caps all requests regardless.
+	Timeout time.Duration
+
+	// Timeouts that override Timeout for requests sent by specific methods, keyed by
+	// the name of the client's method, e.g. "ExportJSON". Calls through the handles,
+	// e.g. BoardClient.ExportJSON, use the timeout of the client's method they wrap.
+	// If a method calls other methods, the timeout of the innermost one applies.
+	// Like Timeout, they only apply if the context passed to the method has no deadline.
+	OperationTimeouts map[string]time.Duration
+
+	// The number of times a request is retried, if it failed temporarily.
+	// Throttled requests (429) are retried after the delay requested by the server.
+	// Network failures and server errors are retried with exponential backoff,
+	// except for POST requests.
+	// The timeouts of a request span all of its attempts.
+	// If 0, requests are not retried.
+	MaxRetries int
+
+	// The maximum time the client waits before retrying a throttled request,
+	// regardless of the server's Retry-After header.
+	// Defaults to 1 minute.
+	MaxRetryAfter time.Duration
+
+	// The layout of the token expiry time stamp the server sends on login.
+	// Defaults to time.RFC3339.
+	TokenExpiresFormat string
+
+	// The lifetime assumed for a token, whose expiry time stamp can not be parsed.
+	// If negative, such a login fails instead.
+	// Defaults to 1 hour.
+	DefaultTokenTTL time.Duration
+
+	// The path of the login route, relative to RemoteAddr.
+	// Defaults to "/users/login".
+	// Note: Unlike all other routes, Wekan serves the login and register
+	// routes outside of the "/api" path.
+	LoginPath string
+
+	// The path of the register route, relative to RemoteAddr.
+	// Defaults to "/users/register".
+	RegisterPath string
+
+	// If true, the API token is sent as "authToken" query parameter instead of the
+	// Authorization header, for servers or proxies that only accept it this way.
+	// Routes that require the query parameter, like the board export, always use it.
+	// Tokens in URLs are redacted from returned errors.
+	AuthViaQueryParam bool
+
+	// Additional form parameters sent with every login request, e.g. for auth
+	// plugins like LDAP that accept further fields.
+	// They can not override the username and password.
+	ExtraLoginParams url.Values
+}
+
+// clone returns a copy of o that shares no mutable state with it.
+// All reference-typed fields (slices, maps, ...) must be deep copied here.
+func (o Options) clone() Options {
+	if o.OperationTimeouts != nil {
+		timeouts := make(map[string]time.Duration, len(o.OperationTimeouts))
+		for k, v := range o.OperationTimeouts {
+			timeouts[k] = v
+		}
+		o.OperationTimeouts = timeouts
+	}
+	if o.ExtraLoginParams != nil {
+		params := make(url.Values, len(o.ExtraLoginParams))
+		for k, v := range o.ExtraLoginParams {
+			params[k] = append([]string(nil), v...)
+		}
+		o.ExtraLoginParams = params
+	}
+	return o
 }
 
 type Client struct {
@@ -58,24 +205,65 @@ type Client struct {
 
 	// Unbuffered channel that used to distribute API tokens to the request methods.
 	authChan chan chan string
-
-	mx       sync.Mutex
-	mxUserID string
+	// Unbuffered channel that is used to request a new API token, see ForceRefresh.
+	// Nil, if the client's token can not be renewed.
+	refreshChan chan chan string
+
+	idempotency  idempotencyCache
+	customFields customFieldCache
+	stats        clientStats
+
+	mx             sync.Mutex
+	mxUserID       string
+	mxTokenType    string
+	mxTokenExpires time.Time
 }
 
+// NewClient creates a new client and logs in to the Wekan server, retrying until the
+// login succeeds or the client is closed. It is a shortcut for NewClientContext with
+// context.Background().
 func NewClient(opts Options) (*Client, error) {
+	return NewClientContext(context.Background(), opts)
+}
+
+// NewClientContext creates a new client and logs in to the Wekan server.
+//
+// The lifecycle of the client is split into two phases:
+//   - The initial login is governed by ctx and the client's closer. It is retried until
+//     it succeeds. If ctx is done first, the error of ctx is returned. If the closer is
+//     closed first, a pending login request is aborted and closer.ErrClosed is returned.
+//   - Once NewClientContext returns, ctx is no longer used. The renewal of the token in the
+//     background is governed by the client's closer only and runs until the client is closed.
+func NewClientContext(ctx context.Context, opts Options) (*Client, error) {
+	// Ensure the caller can not alter our options after construction.
+	opts = opts.clone()
+
 	c := &Client{
-		Closer:   opts.Closer,
-		opts:     opts,
-		httpc:    opts.Client,
-		authChan: make(chan chan string),
+		Closer:      opts.Closer,
+		opts:        opts,
+		httpc:       opts.Client,
+		authChan:    make(chan chan string),
+		refreshChan: make(chan chan string),
 	}
 
 	// Assign default values.
-	if opts.Client == nil {
-		c.httpc = &http.Client{
-			Timeout: 30 * time.Second,
-		}
+	if opts.MaxRetryAfter <= 0 {
+		c.opts.MaxRetryAfter = defaultMaxRetryAfter
+	}
+	if opts.TokenExpiresFormat == "" {
+		c.opts.TokenExpiresFormat = time.RFC3339
+	}
+	if opts.DefaultTokenTTL == 0 {
+		c.opts.DefaultTokenTTL = defaultTokenTTL
+	}
+	if opts.LoginPath == "" {
+		c.opts.LoginPath = defaultLoginPath
+	}
+	if opts.RegisterPath == "" {
+		c.opts.RegisterPath = defaultRegisterPath
+	}
+	if opts.Timeout <= 0 {
+		c.opts.Timeout = defaultTimeout
 	}
 	if opts.TimeBetweenLoginAttemps < time.Second {
 		c.opts.TimeBetweenLoginAttemps = time.Second
@@ -83,15 +271,38 @@ func NewClient(opts Options) (*Client, error) {
 	if opts.Closer == nil {
 		c.Closer = closer.New()
 	}
+	if addr := normalizeRemoteAddr(opts.RemoteAddr); addr != opts.RemoteAddr {
+		log.Warn().Str("remoteAddr", opts.RemoteAddr).Str("normalized", addr).Msg("NewClient: normalized RemoteAddr, it must be the bare server address")
+		c.opts.RemoteAddr = addr
+	}
+	if opts.MaxConcurrency <= 0 {
+		c.opts.MaxConcurrency = defaultMaxConcurrency
+	}
+	if opts.RequestIDHeader == "" {
+		c.opts.RequestIDHeader = defaultRequestIDHeader
+	}
+	if opts.Client == nil {
+		c.httpc = newDefaultHTTPClient(c.opts)
+	}
 
-	// Start routines.
-	ctx, cancel := c.Context()
+	// The initial login ends, once either ctx is done or the client is closed.
+	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
+	go func() {
+		select {
+		case <-c.ClosingChan():
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
 
 	// Request the first token.
-	// Error can only be a context.ErrCanceled.
+	// Error can only be an error of ctx, which is canceled as well, if the client is closed.
 	token, tokenExpires, err := c.loginUntilSuccess(ctx)
 	if err != nil {
+		if c.IsClosing() {
+			err = closer.ErrClosed
+		}
 		return nil, err
 	}
 
@@ -100,6 +311,58 @@ func NewClient(opts Options) (*Client, error) {
 	return c, nil
 }
 
+// newDefaultHTTPClient returns the http client used, if Options.Client is nil.
+// Its transport keeps enough idle connections to serve MaxConcurrency requests at once.
+func newDefaultHTTPClient(opts Options) *http.Client {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.MaxIdleConnsPerHost = opts.MaxIdleConnsPerHost
+	if t.MaxIdleConnsPerHost <= 0 {
+		t.MaxIdleConnsPerHost = opts.MaxConcurrency
+	}
+	t.MaxConnsPerHost = opts.MaxConnsPerHost
+	t.DisableCompression = opts.DisableCompression
+
+	// Timeouts are applied per request, see Options.Timeout.
+	return &http.Client{Transport: t}
+}
+
+// normalizeRemoteAddr removes trailing slashes and a trailing "/api" path from addr,
+// which would otherwise be doubled by the endpoints.
+func normalizeRemoteAddr(addr string) string {
+	addr = strings.TrimRight(addr, "/")
+	return strings.TrimRight(strings.TrimSuffix(addr, "/api"), "/")
+}
+
+// NewImpersonatedClient returns a client that acts as the user with the given id.
+// It authenticates with a login token that is created with CreateUserToken,
+// therefore c must be logged in as admin. The token is not renewed.
+//
+// The returned client has its own closer, derived from the closer of c:
+// Closing it does not affect c, but closing c closes it as well.
+// Callers should close it once it is no longer needed.
+func (c *Client) NewImpersonatedClient(ctx context.Context, userID string) (*Client, error) {
+	r, err := c.CreateUserToken(ctx, userID)
+	if err != nil {
+		return nil, err
+	} else if r.AuthToken == "" {
+		return nil, errors.New("server did not return an auth token")
+	}
+
+	ic := &Client{
+		Closer:   c.CloserOneWay(),
+		opts:     c.opts.clone(),
+		httpc:    c.httpc,
+		authChan: make(chan chan string),
+		mxUserID: userID,
+	}
+	ic.opts.Closer = ic.Closer
+
+	ic.CloserAddWait(1)
+	go ic.staticTokenRoutine(r.AuthToken)
+
+	return ic, nil
+}
+
 func (c *Client) startConnectionRoutine(token string, tokenExpires time.Time) {
 	c.CloserAddWait(1)
 	go c.connectionRoutine(token, tokenExpires)
@@ -139,6 +402,42 @@ func (c *Client) connectionRoutine(token string, tokenExpires time.Time) {
 			// Restart the timer to renew our token.
 			expires.Reset(time.Until(tokenExpires) - 5*time.Second)
 
+		case tokenChan := <-c.authChan:
+			// Buffered channel, no select needed.
+			tokenChan <- token
+
+		case tokenChan := <-c.refreshChan:
+			// A fresh token has been requested, see ForceRefresh.
+			token, tokenExpires, err = c.loginUntilSuccess(ctx)
+			if err != nil {
+				if !errors.Is(err, context.Canceled) {
+					log.Error().Err(err).Msg("connectionRoutine")
+				}
+				return
+			}
+
+			// Restart the timer to renew our token.
+			if !expires.Stop() {
+				<-expires.C
+			}
+			expires.Reset(time.Until(tokenExpires) - 5*time.Second)
+
+			// Buffered channel, no select needed.
+			tokenChan <- token
+		}
+	}
+}
+
+// staticTokenRoutine distributes the given token, which is never renewed.
+func (c *Client) staticTokenRoutine(token string) {
+	defer c.CloseAndDone_()
+
+	closingChan := c.ClosingChan()
+	for {
+		select {
+		case <-closingChan:
+			return
+
 		case tokenChan := <-c.authChan:
 			// Buffered channel, no select needed.
 			tokenChan <- token
@@ -160,7 +459,15 @@ func (c *Client) loginUntilSuccess(ctx context.Context) (token string, tokenExpi
 			}
 
 			log.Error().Err(err).Msg("connectionRoutine: login")
-			time.Sleep(c.opts.TimeBetweenLoginAttemps)
+
+			t := time.NewTimer(c.opts.TimeBetweenLoginAttemps)
+			select {
+			case <-ctx.Done():
+				t.Stop()
+				err = ctx.Err()
+				return
+			case <-t.C:
+			}
 			continue
 		}
 
@@ -168,34 +475,128 @@ func (c *Client) loginUntilSuccess(ctx context.Context) (token string, tokenExpi
 		token = resp.Token
 		tokenExpires = resp.TokenExpires
 
-		// Save the user's id.
+		c.stats.tokenRenewals.Add(1)
+
+		// Save the user's id and the token's expiry.
 		c.mx.Lock()
 		c.mxUserID = resp.ID
+		c.mxTokenType = resp.TokenType
+		c.mxTokenExpires = tokenExpires
 		c.mx.Unlock()
 		return
 	}
 }
 
+// WaitReady blocks until the client holds a valid API token, or until ctx is done.
+// It sends no request itself, but waits for the login performed by the client.
+//
+// Returns closer.ErrClosed, if the client has been closed.
+func (c *Client) WaitReady(ctx context.Context) error {
+	_, err := c.token(ctx)
+	return err
+}
+
+// TokenExpires returns the time the client's current API token expires.
+// The client renews the token shortly before.
+// It sends no request.
+func (c *Client) TokenExpires() (t time.Time) {
+	c.mx.Lock()
+	t = c.mxTokenExpires
+	c.mx.Unlock()
+	return
+}
+
+// TokenTTL returns the time until the client's current API token expires.
+// It is negative, if the token has already expired.
+// It sends no request.
+func (c *Client) TokenTTL() time.Duration {
+	return time.Until(c.TokenExpires())
+}
+
+// tokenType returns the scheme of the current API token, see LoginResponse.TokenType.
+func (c *Client) tokenType() (t string) {
+	c.mx.Lock()
+	t = c.mxTokenType
+	c.mx.Unlock()
+
+	if t == "" {
+		t = defaultTokenType
+	}
+	return
+}
+
+// HTTPClient returns the http client used to send all requests, which is either
+// Options.Client or the default client created by NewClient.
+// Its settings, e.g. the transport, may be adjusted, but not while requests are in flight,
+// since the http.Client is not safe for concurrent modification.
+func (c *Client) HTTPClient() *http.Client {
+	return c.httpc
+}
+
+// authenticateRequest adds the API token to req, either as Authorization header or,
+// if Options.AuthViaQueryParam is set, as query parameter.
 func (c *Client) authenticateRequest(ctx context.Context, req *http.Request) error {
 	token, err := c.token(ctx)
 	if err != nil {
 		return err
 	}
 
-	req.Header.Set("Authorization", "Bearer "+token)
+	if c.opts.AuthViaQueryParam {
+		setQueryToken(req, token)
+	} else {
+		req.Header.Set("Authorization", c.tokenType()+" "+token)
+	}
 	return nil
 }
 
+// authenticateRequestViaQuery adds the API token to req as query parameter, regardless of
+// Options.AuthViaQueryParam. Some routes, e.g. the board export, only accept it this way.
+func (c *Client) authenticateRequestViaQuery(ctx context.Context, req *http.Request) error {
+	token, err := c.token(ctx)
+	if err != nil {
+		return err
+	}
+
+	setQueryToken(req, token)
+	return nil
+}
+
+// setQueryToken sets the token as query parameter of req.
+func setQueryToken(req *http.Request, token string) {
+	q := req.URL.Query()
+	q.Set(authTokenParam, token)
+	req.URL.RawQuery = q.Encode()
+}
+
+// redactURL returns u with the value of the token query parameter replaced,
+// so that it can be logged or returned in errors.
+func redactURL(u *url.URL) string {
+	q := u.Query()
+	if !q.Has(authTokenParam) {
+		return u.String()
+	}
+
+	q.Set(authTokenParam, "REDACTED")
+	r := *u
+	r.RawQuery = q.Encode()
+	return r.String()
+}
+
 func (c *Client) token(ctx context.Context) (string, error) {
 	// Buffered so the connection routine can immediately resume its work.
 	tokenChan := make(chan string, 1)
 
+	reqChan := c.authChan
+	if c.refreshChan != nil && takeForceRefresh(ctx) {
+		reqChan = c.refreshChan
+	}
+
 	select {
 	case <-c.ClosingChan():
 		return "", closer.ErrClosed
 	case <-ctx.Done():
 		return "", ctx.Err()
-	case c.authChan <- tokenChan:
+	case reqChan <- tokenChan:
 	}
 
 	select {
@@ -208,6 +609,25 @@ func (c *Client) token(ctx context.Context) (string, error) {
 	}
 }
 
+type forceRefreshContextKey struct{}
+
+// ForceRefresh returns a copy of ctx that makes the client log in again and use the new
+// API token for the first request made with it, instead of the cached token.
+// This is useful, e.g., after the credentials have been rotated.
+// Other requests are not affected, but use the new token once it has been obtained.
+// Clients created with NewImpersonatedClient ignore it, since their token can not be renewed.
+// It costs one additional login request.
+func ForceRefresh(ctx context.Context) context.Context {
+	return context.WithValue(ctx, forceRefreshContextKey{}, new(atomic.Bool))
+}
+
+// takeForceRefresh reports, whether ctx demands a new token, see ForceRefresh.
+// It reports true only once per context created by ForceRefresh.
+func takeForceRefresh(ctx context.Context) bool {
+	taken, ok := ctx.Value(forceRefreshContextKey{}).(*atomic.Bool)
+	return ok && taken.CompareAndSwap(false, true)
+}
+
 func (c *Client) endpoint(segments ...string) string {
 	return "/api/" + filepath.Join(segments...)
 }
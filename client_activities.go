@@ -0,0 +1,207 @@
+/**
+ * Copyright (c) 2023 Sebastian Borchers
+ *
+ * This software is released under the MIT License.
+ * https://opensource.org/licenses/MIT
+ */
+
+package wego
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// GetBoardActivities returns the activities of a board, newest first.
+// It performs a single board export request (see ExportJSON) and extracts the activities
+// from it. Skip and Limit are applied on the client side, so every page costs a full export.
+func (c *Client) GetBoardActivities(ctx context.Context, boardID string, opts ActivitiesOptions) (activities []Activity, err error) {
+	return c.getActivities(ctx, boardID, opts, func(Activity) bool { return true })
+}
+
+// GetCardActivities returns the activities of a card, newest first.
+// Like GetBoardActivities, it costs a full board export request, whose activities are
+// filtered by the card on the client side.
+func (c *Client) GetCardActivities(ctx context.Context, boardID, cardID string, opts ActivitiesOptions) (activities []Activity, err error) {
+	return c.getActivities(ctx, boardID, opts, func(a Activity) bool { return a.CardID == cardID })
+}
+
+//################//
+//### Internal ###//
+//################//
+
+// getActivities returns the paginated activities of the board that match the filter, newest first.
+func (c *Client) getActivities(ctx context.Context, boardID string, opts ActivitiesOptions, filter func(Activity) bool) (activities []Activity, err error) {
+	err = opts.validate()
+	if err != nil {
+		return
+	}
+
+	boardJSON, err := c.ExportJSON(ctx, boardID)
+	if err != nil {
+		return
+	}
+
+	var export struct {
+		Activities []Activity `json:"activities"`
+	}
+	err = json.Unmarshal(boardJSON, &export)
+	if err != nil {
+		err = fmt.Errorf("failed to unmarshal board export: %v", err)
+		return
+	}
+
+	for _, a := range export.Activities {
+		if filter(a) {
+			activities = append(activities, a)
+		}
+	}
+
+	sort.SliceStable(activities, func(i, j int) bool {
+		return activities[i].CreatedAt.After(activities[j].CreatedAt)
+	})
+
+	// Apply pagination.
+	if opts.Skip >= len(activities) {
+		return nil, nil
+	}
+	activities = activities[opts.Skip:]
+	if opts.Limit > 0 && opts.Limit < len(activities) {
+		activities = activities[:opts.Limit]
+	}
+
+	return
+}
+
+//#############//
+//### Types ###//
+//#############//
+
+type ActivitiesOptions struct {
+	// The maximum number of activities to return.
+	// If 0, all activities are returned.
+	Limit int
+	// The number of activities to skip.
+	Skip int
+}
+
+func (o ActivitiesOptions) validate() error {
+	if o.Limit < 0 {
+		return fmt.Errorf("invalid activities limit '%d': must not be negative", o.Limit)
+	} else if o.Skip < 0 {
+		return fmt.Errorf("invalid activities skip '%d': must not be negative", o.Skip)
+	}
+	return nil
+}
+
+type Activity struct {
+	ID              string       `json:"_id"`
+	ActivityType    ActivityType `json:"activityType"`
+	UserID          string       `json:"userId"`
+	BoardID         string       `json:"boardId"`
+	ListID          string       `json:"listId"`
+	OldListID       string       `json:"oldListId"`
+	SwimlaneID      string       `json:"swimlaneId"`
+	OldSwimlaneID   string       `json:"oldSwimlaneId"`
+	CardID          string       `json:"cardId"`
+	CommentID       string       `json:"commentId"`
+	ChecklistID     string       `json:"checklistId"`
+	ChecklistItemID string       `json:"checklistItemId"`
+	AttachmentID    string       `json:"attachmentId"`
+	CustomFieldID   string       `json:"customFieldId"`
+	LabelID         string       `json:"labelId"`
+	MemberID        string       `json:"memberId"`
+	CreatedAt       time.Time    `json:"createdAt"`
+	ModifiedAt      time.Time    `json:"modifiedAt"`
+}
+
+// ActivityType is the type of an activity.
+// Wekan may report types that have no constant in this package.
+type ActivityType string
+
+const (
+	ActivityTypeCreateBoard          ActivityType = "createBoard"
+	ActivityTypeCreateList           ActivityType = "createList"
+	ActivityTypeArchivedList         ActivityType = "archivedList"
+	ActivityTypeCreateSwimlane       ActivityType = "createSwimlane"
+	ActivityTypeArchivedSwimlane     ActivityType = "archivedSwimlane"
+	ActivityTypeCreateCard           ActivityType = "createCard"
+	ActivityTypeMoveCard             ActivityType = "moveCard"
+	ActivityTypeMoveCardToOtherBoard ActivityType = "moveCardToOtherBoard"
+	ActivityTypeArchivedCard         ActivityType = "archivedCard"
+	ActivityTypeRestoredCard         ActivityType = "restoredCard"
+	ActivityTypeAddComment           ActivityType = "addComment"
+	ActivityTypeEditComment          ActivityType = "editComment"
+	ActivityTypeDeleteComment        ActivityType = "deleteComment"
+	ActivityTypeAddAttachment        ActivityType = "addAttachment"
+	ActivityTypeDeleteAttachment     ActivityType = "deleteAttachment"
+	ActivityTypeAddChecklist         ActivityType = "addChecklist"
+	ActivityTypeRemoveChecklist      ActivityType = "removeChecklist"
+	ActivityTypeAddChecklistItem     ActivityType = "addChecklistItem"
+	ActivityTypeCheckedItem          ActivityType = "checkedItem"
+	ActivityTypeUncheckedItem        ActivityType = "uncheckedItem"
+	ActivityTypeCompleteChecklist    ActivityType = "completeChecklist"
+	ActivityTypeJoinMember           ActivityType = "joinMember"
+	ActivityTypeUnjoinMember         ActivityType = "unjoinMember"
+	ActivityTypeAddBoardMember       ActivityType = "addBoardMember"
+	ActivityTypeRemoveBoardMember    ActivityType = "removeBoardMember"
+	ActivityTypeAddedLabel           ActivityType = "addedLabel"
+	ActivityTypeRemovedLabel         ActivityType = "removedLabel"
+	ActivityTypeSetCustomField       ActivityType = "setCustomField"
+	ActivityTypeUnsetCustomField     ActivityType = "unsetCustomField"
+
+	// ActivityTypeAll matches all activity types in the activity filter of an integration.
+	ActivityTypeAll ActivityType = "all"
+)
+
+var knownActivityTypes = map[ActivityType]struct{}{
+	ActivityTypeCreateBoard:          {},
+	ActivityTypeCreateList:           {},
+	ActivityTypeArchivedList:         {},
+	ActivityTypeCreateSwimlane:       {},
+	ActivityTypeArchivedSwimlane:     {},
+	ActivityTypeCreateCard:           {},
+	ActivityTypeMoveCard:             {},
+	ActivityTypeMoveCardToOtherBoard: {},
+	ActivityTypeArchivedCard:         {},
+	ActivityTypeRestoredCard:         {},
+	ActivityTypeAddComment:           {},
+	ActivityTypeEditComment:          {},
+	ActivityTypeDeleteComment:        {},
+	ActivityTypeAddAttachment:        {},
+	ActivityTypeDeleteAttachment:     {},
+	ActivityTypeAddChecklist:         {},
+	ActivityTypeRemoveChecklist:      {},
+	ActivityTypeAddChecklistItem:     {},
+	ActivityTypeCheckedItem:          {},
+	ActivityTypeUncheckedItem:        {},
+	ActivityTypeCompleteChecklist:    {},
+	ActivityTypeJoinMember:           {},
+	ActivityTypeUnjoinMember:         {},
+	ActivityTypeAddBoardMember:       {},
+	ActivityTypeRemoveBoardMember:    {},
+	ActivityTypeAddedLabel:           {},
+	ActivityTypeRemovedLabel:         {},
+	ActivityTypeSetCustomField:       {},
+	ActivityTypeUnsetCustomField:     {},
+}
+
+// Known reports, whether t is one of the activity types defined in this package.
+func (t ActivityType) Known() bool {
+	_, ok := knownActivityTypes[t]
+	return ok
+}
+
+// validateActivityFilter ensures that all types of an integration's activity filter are known,
+// since Wekan silently ignores invalid ones.
+func validateActivityFilter(types []ActivityType) error {
+	for _, t := range types {
+		if t != ActivityTypeAll && !t.Known() {
+			return fmt.Errorf("unknown activity type '%s' in activity filter", t)
+		}
+	}
+	return nil
+}
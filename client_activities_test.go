@@ -0,0 +1,29 @@
+/**
+ * Copyright (c) 2023 Sebastian Borchers
+ *
+ * This software is released under the MIT License.
+ * https://opensource.org/licenses/MIT
+ */
+
+package wego
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestGetBoardActivitiesRejectsNegativePagination(t *testing.T) {
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request to '%s'", r.URL.Path)
+		http.NotFound(w, r)
+	})
+	c := newTestClient(t, srv, Options{})
+
+	for _, opts := range []ActivitiesOptions{{Skip: -1}, {Limit: -1}} {
+		_, err := c.GetBoardActivities(context.Background(), "b", opts)
+		if err == nil {
+			t.Fatalf("expected an error for %+v", opts)
+		}
+	}
+}
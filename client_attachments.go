@@ -0,0 +1,194 @@
+/**
+ * Copyright (c) 2023 Sebastian Borchers
+ *
+ * This software is released under the MIT License.
+ * https://opensource.org/licenses/MIT
+ */
+
+package wego
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+)
+
+// DownloadAttachment writes the content of the attachment to w.
+// It performs a single attachment export request, which sends the content base64 encoded
+// along with the data of the board. The content is decoded while it is written to w,
+// so w may hold a partial attachment, if an error is returned.
+//
+// Returns ErrNotFound, if the attachment could not be found.
+func (c *Client) DownloadAttachment(ctx context.Context, boardID, attachmentID string, w io.Writer) (err error) {
+	return c.copyAttachment(ctx, boardID, attachmentID, func() (io.Writer, error) { return w, nil })
+}
+
+// DownloadBoardAttachments writes a zip archive with all attachments of the board to w.
+// The entries are named "<cardID>/<attachmentName>". If a card has multiple attachments
+// with the same name, the id of the attachment is prepended to the name.
+// It performs a get_board_attachments request and one attachment export request per
+// attachment, see DownloadAttachment. The attachments are downloaded one after another
+// and each one is decoded straight into its zip entry.
+//
+// If some attachments fail to download, the archive holds the remaining ones and
+// a *BatchError is returned, keyed by the index of the attachment in the result of
+// GetBoardAttachments. An attachment failing midway may leave a truncated entry.
+func (c *Client) DownloadBoardAttachments(ctx context.Context, boardID string, w io.Writer) (err error) {
+	attachments, err := c.GetBoardAttachments(ctx, boardID)
+	if err != nil {
+		return
+	}
+
+	var (
+		zw    = zip.NewWriter(w)
+		names = make(map[string]struct{}, len(attachments))
+		errs  = make(map[int]error)
+	)
+
+	for i, a := range attachments {
+		// The entry is only created once the attachment has been found in the export.
+		err = c.copyAttachment(ctx, boardID, a.AttachmentID, func() (io.Writer, error) {
+			f, err := zw.Create(attachmentEntryName(a, names))
+			if err != nil {
+				return nil, fmt.Errorf("failed to create zip entry: %w", err)
+			}
+			return f, nil
+		})
+		if err != nil {
+			errs[i] = fmt.Errorf("attachment '%s': %w", a.AttachmentID, err)
+		}
+	}
+
+	err = zw.Close()
+	if err != nil {
+		err = fmt.Errorf("failed to close zip archive: %w", err)
+	} else if len(errs) > 0 {
+		err = &BatchError{Errors: errs}
+	}
+	return
+}
+
+//################//
+//### Internal ###//
+//################//
+
+// copyAttachment streams the attachment export of the attachment and writes the decoded
+// content to the writer returned by open. Only the base64 encoded content of the
+// attachment itself is held in memory, since it is embedded into the JSON response.
+// Options.MaxResponseBytes does not apply.
+func (c *Client) copyAttachment(ctx context.Context, boardID, attachmentID string, open func() (io.Writer, error)) (err error) {
+	endpoint := c.endpoint("boards", boardID, "attachments", attachmentID, "export")
+
+	req, err := c.newGETRequest(ctx, endpoint)
+	if err != nil {
+		return
+	}
+
+	// Like the board export, the route expects the token as query parameter.
+	err = c.authenticateRequestViaQuery(ctx, req)
+	if err != nil {
+		return
+	}
+
+	return c.doStreamRequest(req, func(body io.Reader) (err error) {
+		a, err := findExportedAttachment(body, attachmentID)
+		if err != nil {
+			return
+		}
+
+		w, err := open()
+		if err != nil {
+			return
+		}
+
+		_, err = io.Copy(w, base64.NewDecoder(base64.StdEncoding, strings.NewReader(a.File)))
+		if err != nil {
+			return fmt.Errorf("failed to write attachment: %w", err)
+		}
+		return nil
+	})
+}
+
+// findExportedAttachment decodes the attachment export read from r and returns the
+// attachment with the given id. The other attachments are decoded and dropped one at a time.
+// Returns ErrNotFound, if the export does not hold the attachment.
+func findExportedAttachment(r io.Reader, attachmentID string) (a exportedAttachment, err error) {
+	dec := json.NewDecoder(r)
+
+	t, err := dec.Token()
+	if err != nil {
+		err = fmt.Errorf("failed to decode response: %v", err)
+		return
+	} else if d, ok := t.(json.Delim); !ok || d != '{' {
+		err = fmt.Errorf("failed to decode response: expected JSON object, got '%v'", t)
+		return
+	}
+
+	for dec.More() {
+		t, err = dec.Token()
+		if err != nil {
+			err = fmt.Errorf("failed to decode response: %v", err)
+			return
+		}
+
+		if t != "attachments" {
+			var skip json.RawMessage
+			err = dec.Decode(&skip)
+			if err != nil {
+				err = fmt.Errorf("failed to decode response: %v", err)
+				return
+			}
+			continue
+		}
+
+		found := false
+		err = decodeNextJSONArray(dec, func(v exportedAttachment) bool {
+			if v.ID == attachmentID {
+				a, found = v, true
+			}
+			return !found
+		})
+		if err != nil || found {
+			return
+		}
+	}
+
+	err = fmt.Errorf("attachment '%s': %w", attachmentID, ErrNotFound)
+	return
+}
+
+// attachmentEntryName returns the unique name of the zip entry of the attachment and
+// adds it to the used names.
+func attachmentEntryName(a BoardAttachment, used map[string]struct{}) string {
+	// Prevent the names from escaping their card's directory.
+	base := path.Base(strings.ReplaceAll(a.AttachmentName, "\\", "/"))
+	if base == "." || base == "/" || base == ".." {
+		base = a.AttachmentID
+	}
+
+	name := path.Join(a.CardID, base)
+	if _, ok := used[name]; ok {
+		name = path.Join(a.CardID, a.AttachmentID+"_"+base)
+	}
+
+	used[name] = struct{}{}
+	return name
+}
+
+//#############//
+//### Types ###//
+//#############//
+
+type exportedAttachment struct {
+	ID     string `json:"_id"`
+	CardID string `json:"cardId"`
+	// The base64 encoded content.
+	File string `json:"file"`
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
@@ -0,0 +1,96 @@
+/**
+ * Copyright (c) 2023 Sebastian Borchers
+ *
+ * This software is released under the MIT License.
+ * https://opensource.org/licenses/MIT
+ */
+
+package wego
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestDownloadBoardAttachments(t *testing.T) {
+	contents := map[string]string{
+		"a1": "first attachment",
+		"a2": "second attachment",
+	}
+
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/boards/b/attachments" {
+			writeJSON(w, []BoardAttachment{
+				{AttachmentID: "a1", AttachmentName: "file.txt", CardID: "c1"},
+				{AttachmentID: "missing", AttachmentName: "gone.txt", CardID: "c1"},
+				{AttachmentID: "a2", AttachmentName: "file.txt", CardID: "c1"},
+			})
+			return
+		}
+
+		id := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/api/boards/b/attachments/"), "/export")
+		if r.URL.Query().Get(authTokenParam) != testToken {
+			writeErrorStatus(w, http.StatusUnauthorized)
+			return
+		}
+
+		// The export holds other board data and attachments around the requested one.
+		export := map[string]any{
+			"_id":   "b",
+			"lists": []map[string]string{{"_id": "l"}},
+			"attachments": []exportedAttachment{
+				{ID: "other", File: base64.StdEncoding.EncodeToString([]byte("other"))},
+			},
+			"title": "board",
+		}
+		if content, ok := contents[id]; ok {
+			export["attachments"] = append(export["attachments"].([]exportedAttachment),
+				exportedAttachment{ID: id, File: base64.StdEncoding.EncodeToString([]byte(content))})
+		}
+		writeJSON(w, export)
+	})
+	c := newTestClient(t, srv, Options{})
+
+	var buf bytes.Buffer
+	err := c.DownloadBoardAttachments(context.Background(), "b", &buf)
+
+	var batchErr *BatchError
+	if !errors.As(err, &batchErr) {
+		t.Fatalf("expected a *BatchError, got: %v", err)
+	} else if len(batchErr.Errors) != 1 || !errors.Is(batchErr.Errors[1], ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for the attachment at index 1, got: %v", batchErr.Errors)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]string{
+		"c1/file.txt":    contents["a1"],
+		"c1/a2_file.txt": contents["a2"],
+	}
+	if len(zr.File) != len(want) {
+		t.Fatalf("expected %d zip entries, got %d", len(want), len(zr.File))
+	}
+	for _, f := range zr.File {
+		r, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, err := io.ReadAll(r)
+		r.Close()
+		if err != nil {
+			t.Fatal(err)
+		} else if content, ok := want[f.Name]; !ok || string(data) != content {
+			t.Fatalf("unexpected zip entry '%s' with content '%s'", f.Name, data)
+		}
+	}
+}
@@ -11,7 +11,11 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
+	"net/http"
+	"strings"
+	"sync"
 	"time"
 )
 
@@ -37,9 +41,15 @@ func (c *Client) GetPublicBoards(ctx context.Context) (boards []GetPublicBoard,
 // See https://wekan.github.io/api/v5.13/#new_board
 //
 // Note: Owner must be a userID, not an email or username.
+// The Wekan API offers no route to edit a board afterwards, so its color must be set here.
 func (c *Client) NewBoard(ctx context.Context, request NewBoardRequest) (r NewBoardResponse, err error) {
 	endpoint := c.endpoint("boards")
 
+	if request.Color != "" && !request.Color.Valid() {
+		err = fmt.Errorf("invalid board color '%s'", request.Color)
+		return
+	}
+
 	req, err := c.newAuthenticatedPOSTRequest(ctx, endpoint, request)
 	if err != nil {
 		return
@@ -53,6 +63,75 @@ func (c *Client) NewBoard(ctx context.Context, request NewBoardRequest) (r NewBo
 	return
 }
 
+// NewBoardFromTemplate creates a new board and provisions it with the lists and
+// swimlanes of the given template board.
+// It performs a get_all_lists, get_all_swimlanes, new_board and get_swimlane request,
+// followed by a new_list request per list and a new_swimlane request per swimlane of
+// the template. See GetAllListsSorted for Wekan versions, that require a get_list
+// request per list in addition.
+//
+// Note: The Wekan API offers no route to copy a board, therefore the new board is
+// created with NewBoard and the lists and swimlanes of the template are recreated.
+// The lists keep the order of the template, the swimlanes are created in the order
+// returned by the server. Cards and labels are not copied.
+// The new board comes with a default swimlane, that can not be renamed via the API.
+// The template's swimlane with the same title is mapped to it, all other swimlanes are
+// created. Thus, if the template's default swimlane has been renamed, the new board holds
+// it in addition to its own default swimlane.
+// If provisioning fails, the partially provisioned board is not removed.
+func (c *Client) NewBoardFromTemplate(ctx context.Context, templateBoardID string, request NewBoardRequest) (r NewBoardResponse, err error) {
+	lists, err := c.GetAllListsSorted(ctx, templateBoardID)
+	if err != nil {
+		return
+	}
+
+	swimlanes, err := c.GetAllSwimlanes(ctx, templateBoardID)
+	if err != nil {
+		return
+	}
+
+	r, err = c.NewBoard(ctx, request)
+	if err != nil {
+		return
+	}
+
+	// Determine the title of the swimlane the new board came with.
+	defaultTitle := defaultSwimlaneTitle
+	if r.DefaultSwimlaneID != "" {
+		var sl GetSwimlane
+		sl, err = c.GetSwimlane(ctx, r.ID, r.DefaultSwimlaneID)
+		if err != nil {
+			err = fmt.Errorf("failed to get default swimlane: %w", err)
+			return
+		}
+		defaultTitle = sl.Title
+	}
+
+	for _, l := range lists {
+		_, err = c.NewList(ctx, r.ID, l.Title)
+		if err != nil {
+			err = fmt.Errorf("failed to copy list '%s': %w", l.Title, err)
+			return
+		}
+	}
+
+	mapped := false
+	for _, sl := range swimlanes {
+		if !mapped && sl.Title == defaultTitle {
+			mapped = true
+			continue
+		}
+
+		_, err = c.NewSwimlane(ctx, r.ID, sl.Title)
+		if err != nil {
+			err = fmt.Errorf("failed to copy swimlane '%s': %w", sl.Title, err)
+			return
+		}
+	}
+
+	return
+}
+
 // GetBoard performs a get_board request against the Wekan server.
 // See https://wekan.github.io/api/v5.13/#get_board
 //
@@ -76,8 +155,165 @@ func (c *Client) GetBoard(ctx context.Context, boardID string) (r GetBoard, err
 	return
 }
 
+// GetBoardSummary returns the title, permission and archive state of a board.
+// It is a lightweight alternative to GetBoard, if no members, labels or settings are needed.
+// It performs a single get_board request.
+//
+// Note: The Wekan API does not support field projection, therefore the server still
+// transfers the full board. Only decoding the remaining fields is saved.
+//
+// Returns ErrNotFound, if the board could not be found.
+func (c *Client) GetBoardSummary(ctx context.Context, boardID string) (r BoardSummary, err error) {
+	endpoint := c.endpoint("boards", boardID)
+
+	req, err := c.newAuthenticatedGETRequest(ctx, endpoint)
+	if err != nil {
+		return
+	}
+
+	err = c.doSimpleRequest(req, &r)
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			err = ErrNotFound
+		}
+		return
+	}
+
+	r.ID = boardID
+	return
+}
+
+// GetBoardFull fetches the board, including its labels, together with its lists and
+// swimlanes and, if requested by opts, the cards of all lists.
+// The requests are sent concurrently, see Options.MaxConcurrency.
+// It performs a get_board, get_all_lists and get_all_swimlanes request and, if cards are
+// requested, a get_all_cards request per list.
+//
+// If some of the requests fail, the returned BoardFull holds the results of the successful
+// ones and a *BatchError is returned, whose errors name the failed parts.
+func (c *Client) GetBoardFull(ctx context.Context, boardID string, opts BoardFullOptions) (r BoardFull, err error) {
+	r.ID = boardID
+
+	tasks := []func(ctx context.Context) error{
+		func(ctx context.Context) (err error) {
+			r.Board, err = c.GetBoard(ctx, boardID)
+			if err != nil {
+				return fmt.Errorf("board: %w", err)
+			}
+			return nil
+		},
+		func(ctx context.Context) (err error) {
+			r.Lists, err = c.GetAllLists(ctx, boardID)
+			if err != nil {
+				return fmt.Errorf("lists: %w", err)
+			}
+			return nil
+		},
+		func(ctx context.Context) (err error) {
+			r.Swimlanes, err = c.GetAllSwimlanes(ctx, boardID)
+			if err != nil {
+				return fmt.Errorf("swimlanes: %w", err)
+			}
+			return nil
+		},
+	}
+
+	err = c.forEachConcurrent(ctx, len(tasks), func(ctx context.Context, i int) error {
+		return tasks[i](ctx)
+	})
+	if err != nil || !opts.IncludeCards {
+		return
+	}
+
+	var mx sync.Mutex
+	r.Cards = make(map[string][]GetAllCard, len(r.Lists))
+
+	err = c.forEachConcurrent(ctx, len(r.Lists), func(ctx context.Context, i int) error {
+		listID := r.Lists[i].ID
+
+		cards, err := c.GetAllCards(ctx, boardID, listID)
+		if err != nil {
+			return fmt.Errorf("cards of list '%s': %w", listID, err)
+		}
+
+		mx.Lock()
+		r.Cards[listID] = cards
+		mx.Unlock()
+		return nil
+	})
+	return
+}
+
+// GetBoardStats returns statistics about the unarchived cards of the board.
+// Cards count as overdue, if their due date has passed and they have no end date.
+//
+// Note: To avoid fetching every card and checklist one by one, the statistics are computed
+// from a single full board export (see ExportJSON), which is expensive for large boards.
+func (c *Client) GetBoardStats(ctx context.Context, boardID string) (stats BoardStats, err error) {
+	boardJSON, err := c.ExportJSON(ctx, boardID)
+	if err != nil {
+		return
+	}
+
+	var export struct {
+		Cards          []GetCard `json:"cards"`
+		ChecklistItems []struct {
+			CardID     string `json:"cardId"`
+			IsFinished bool   `json:"isFinished"`
+		} `json:"checklistItems"`
+	}
+	err = json.Unmarshal(boardJSON, &export)
+	if err != nil {
+		err = fmt.Errorf("failed to unmarshal board export: %v", err)
+		return
+	}
+
+	var (
+		now   = time.Now()
+		cards = make(map[string]struct{}, len(export.Cards))
+	)
+	stats.CardsPerList = make(map[string]int)
+
+	for _, card := range export.Cards {
+		if card.Archived {
+			continue
+		}
+		cards[card.ID] = struct{}{}
+
+		stats.Cards++
+		stats.CardsPerList[card.ListID]++
+
+		if due, ok := card.DueTime(); ok && card.EndAt == "" && due.Before(now) {
+			stats.Overdue++
+		}
+	}
+
+	for _, item := range export.ChecklistItems {
+		if _, ok := cards[item.CardID]; !ok {
+			continue
+		}
+
+		stats.ChecklistItems++
+		if item.IsFinished {
+			stats.ChecklistItemsFinished++
+		}
+	}
+
+	return
+}
+
+// BoardExists reports, whether the board exists.
+// It performs a single get_board request.
+func (c *Client) BoardExists(ctx context.Context, boardID string) (bool, error) {
+	return c.exists(ctx, c.endpoint("boards", boardID))
+}
+
 // DeleteBoard performs a delete_board request against the Wekan server.
 // See https://wekan.github.io/api/v5.13/#delete_board
+//
+// If the server rejects the deletion, e.g. because other boards use the board as default
+// board for subtasks or date settings, an *APIError holding the server's reason is returned.
+// Use errors.As to inspect it.
 func (c *Client) DeleteBoard(ctx context.Context, boardID string) (err error) {
 	endpoint := c.endpoint("boards", boardID)
 
@@ -107,17 +343,30 @@ func (c *Client) GetBoardAttachments(ctx context.Context, boardID string) (attac
 	return
 }
 
-// ExportJSON performs an export_json request against the Wekan server.
-// See https://wekan.github.io/api/v5.13/#exportjson
-func (c *Client) ExportJSON(ctx context.Context, boardID string) (boardJSON json.RawMessage, err error) {
-	token, err := c.token(ctx)
+// GetBoardAttachmentsFiltered performs a get_board_attachments request against the Wekan server
+// and returns only the attachments matching all set fields of the filter.
+//
+// Note: The Wekan API does not support filtering attachments, therefore the filter is
+// applied on the client side and all attachments of the board are transferred.
+func (c *Client) GetBoardAttachmentsFiltered(ctx context.Context, boardID string, filter BoardAttachmentFilter) (attachments []BoardAttachment, err error) {
+	all, err := c.GetBoardAttachments(ctx, boardID)
 	if err != nil {
 		return
 	}
 
-	endpoint := c.endpoint("boards", boardID, "export?authToken="+token)
+	for _, a := range all {
+		if filter.match(a) {
+			attachments = append(attachments, a)
+		}
+	}
+
+	return
+}
 
-	req, err := c.newGETRequest(ctx, endpoint)
+// ExportJSON performs an export_json request against the Wekan server.
+// See https://wekan.github.io/api/v5.13/#exportjson
+func (c *Client) ExportJSON(ctx context.Context, boardID string) (boardJSON json.RawMessage, err error) {
+	req, err := c.newExportRequest(ctx, boardID)
 	if err != nil {
 		return
 	}
@@ -130,11 +379,30 @@ func (c *Client) ExportJSON(ctx context.Context, boardID string) (boardJSON json
 	return
 }
 
+// ExportJSONTo is like ExportJSON, but streams the board export to w, instead of
+// buffering it in memory. This should be preferred for large boards.
+// Options.MaxResponseBytes does not apply.
+func (c *Client) ExportJSONTo(ctx context.Context, boardID string, w io.Writer) (err error) {
+	req, err := c.newExportRequest(ctx, boardID)
+	if err != nil {
+		return
+	}
+
+	return c.doStreamRequest(req, func(body io.Reader) error {
+		_, err := io.Copy(w, body)
+		if err != nil {
+			return fmt.Errorf("failed to copy board export: %w", err)
+		}
+		return nil
+	})
+}
+
 // AddBoardLabel performs an add_board_label request against the Wekan server.
 // See https://wekan.github.io/api/v5.13/#add_board_label
 //
-// Note: Currently broken
-func (c *Client) AddBoardLabel(ctx context.Context, boardID, name, color string) (err error) {
+// The server responds with the id of the new label. If a label with the same name
+// already exists, the server responds with an empty body and labelID is empty.
+func (c *Client) AddBoardLabel(ctx context.Context, boardID, name, color string) (labelID string, err error) {
 	endpoint := c.endpoint("boards", boardID, "labels")
 
 	req, err := c.newAuthenticatedPUTRequest(ctx, endpoint, addBoardLabelRequest{
@@ -147,7 +415,72 @@ func (c *Client) AddBoardLabel(ctx context.Context, boardID, name, color string)
 		return
 	}
 
-	return c.doSimpleRequest(req, nil)
+	err = c.doSimpleRequest(req, &labelID)
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			err = nil
+		}
+		return
+	}
+
+	return
+}
+
+// EnsureBoardLabel returns the label of the board with the given name, creating it
+// with the given color, if it does not exist yet.
+// The color of an existing label is not changed.
+// It performs a get_board request and, if the label is missing, an add_board_label request.
+// If the server does not report the id of the label, another get_board request resolves it.
+func (c *Client) EnsureBoardLabel(ctx context.Context, boardID, name, color string) (label BoardLabel, err error) {
+	label, err = c.GetBoardLabelByName(ctx, boardID, name)
+	if err == nil || !errors.Is(err, ErrNotFound) {
+		return
+	}
+
+	id, err := c.AddBoardLabel(ctx, boardID, name, color)
+	if err != nil {
+		return
+	} else if id == "" {
+		// Resolve the label, if the server did not report its id.
+		return c.GetBoardLabelByName(ctx, boardID, name)
+	}
+
+	label = BoardLabel{ID: id, Name: name, Color: color}
+	return
+}
+
+// GetBoardLabels returns the labels of the board.
+//
+// Note: The Wekan API has no dedicated route for labels, the labels are taken from GetBoard.
+//
+// Returns ErrNotFound, if the board could not be found.
+func (c *Client) GetBoardLabels(ctx context.Context, boardID string) (labels []BoardLabel, err error) {
+	board, err := c.GetBoard(ctx, boardID)
+	if err != nil {
+		return
+	}
+
+	return board.Labels, nil
+}
+
+// GetBoardLabelByName returns the label of the board with the given name.
+// It performs a single get_board request, see GetBoardLabels.
+//
+// Returns ErrNotFound, if the board or the label could not be found.
+func (c *Client) GetBoardLabelByName(ctx context.Context, boardID, name string) (label BoardLabel, err error) {
+	labels, err := c.GetBoardLabels(ctx, boardID)
+	if err != nil {
+		return
+	}
+
+	for _, l := range labels {
+		if l.Name == name {
+			return l, nil
+		}
+	}
+
+	err = ErrNotFound
+	return
 }
 
 // SetBoardMemberPermission performs an set_board_member_permission request against the Wekan server.
@@ -181,10 +514,51 @@ func (c *Client) GetBoardsCount(ctx context.Context) (r GetBoardsCountResponse,
 	return
 }
 
+// GetBoardsModifiedSince returns the boards of the user that have been modified after since.
+//
+// Note: The board listing lacks the modification time, therefore a get_boards_from_user
+// request is followed by a get_board request per board, see GetBoardSummary.
+// The get_board requests are sent concurrently, see Options.MaxConcurrency.
+// Depending on the Wekan version, changes of a board's cards may not update the
+// modification time of the board.
+func (c *Client) GetBoardsModifiedSince(ctx context.Context, userID string, since time.Time) (boards []GetBoardFromUser, err error) {
+	all, err := c.GetBoardsFromUser(ctx, userID)
+	if err != nil {
+		return
+	}
+
+	modified := make([]bool, len(all))
+	err = c.forEachConcurrent(ctx, len(all), func(ctx context.Context, i int) error {
+		b, err := c.GetBoardSummary(ctx, all[i].ID)
+		if err != nil {
+			return fmt.Errorf("board '%s': %w", all[i].ID, err)
+		}
+
+		modified[i] = b.ModifiedAt.After(since)
+		return nil
+	})
+	if err != nil {
+		return
+	}
+
+	for i, b := range all {
+		if modified[i] {
+			boards = append(boards, b)
+		}
+	}
+	return
+}
+
+// GetUserBoards returns the boards the user is a member of.
+// It is an alias of GetBoardsFromUser.
+func (c *Client) GetUserBoards(ctx context.Context, userID string) ([]GetBoardFromUser, error) {
+	return c.GetBoardsFromUser(ctx, userID)
+}
+
 // GetBoardsFromUser performs a get_boards_from_user request against the Wekan server.
 // See https://wekan.github.io/api/v5.13/#get_boards_from_user
 func (c *Client) GetBoardsFromUser(ctx context.Context, userID string) (r []GetBoardFromUser, err error) {
-	endpoint := c.endpoint("users", userID)
+	endpoint := c.endpoint("users", userID, "boards")
 
 	req, err := c.newAuthenticatedGETRequest(ctx, endpoint)
 	if err != nil {
@@ -199,10 +573,30 @@ func (c *Client) GetBoardsFromUser(ctx context.Context, userID string) (r []GetB
 	return
 }
 
+//################//
+//### Internal ###//
+//################//
+
+// newExportRequest creates the request of a board export.
+// The export route expects the token as query parameter instead of the Authorization header.
+func (c *Client) newExportRequest(ctx context.Context, boardID string) (req *http.Request, err error) {
+	req, err = c.newGETRequest(ctx, c.endpoint("boards", boardID, "export"))
+	if err != nil {
+		return
+	}
+
+	err = c.authenticateRequestViaQuery(ctx, req)
+	return
+}
+
 //#############//
 //### Types ###//
 //#############//
 
+// The title of the swimlane Wekan creates for every new board.
+// It is used, if the server does not report the id of the swimlane.
+const defaultSwimlaneTitle = "Default"
+
 type GetPublicBoard struct {
 	ID    string `json:"_id"`
 	Title string `json:"title"`
@@ -224,7 +618,8 @@ type GetBoard struct {
 	Labels                     []BoardLabel  `json:"labels"`
 	Members                    []BoardMember `json:"members"`
 	Permission                 string        `json:"permission"`
-	Color                      string        `json:"color"`
+	Color                      BoardColor    `json:"color"`
+	BackgroundImageURL         string        `json:"backgroundImageURL"`
 	Description                string        `json:"description"`
 	SubtasksDefaultBoardID     string        `json:"subtasksDefaultBoardId"`
 	SubtasksDefaultListID      string        `json:"subtasksDefaultListId"`
@@ -252,10 +647,71 @@ type GetBoard struct {
 	EndAt                      string        `json:"endAt"`
 	SpentTime                  int           `json:"spentTime"`
 	IsOvertime                 bool          `json:"isOvertime"`
-	Type                       string        `json:"type"`
+	Type                       BoardType     `json:"type"`
 	Sort                       float32       `json:"sort"`
 }
 
+type BoardFullOptions struct {
+	// If true, the cards of all lists are fetched as well.
+	IncludeCards bool
+}
+
+type BoardFull struct {
+	ID        string
+	Board     GetBoard
+	Lists     []GetAllList
+	Swimlanes []GetAllSwimlane
+	// The cards keyed by the id of their list.
+	// Only set, if requested with BoardFullOptions.IncludeCards.
+	Cards map[string][]GetAllCard
+}
+
+type BoardStats struct {
+	// The number of unarchived cards.
+	Cards int
+	// The number of unarchived cards keyed by the id of their list.
+	CardsPerList map[string]int
+	// The number of unarchived cards that are overdue.
+	Overdue int
+	// The number of checklist items of the unarchived cards, and how many of them
+	// are finished.
+	ChecklistItems         int
+	ChecklistItemsFinished int
+}
+
+// ChecklistCompletion returns the ratio of finished checklist items in [0, 1].
+// It is 0, if there are no checklist items.
+func (s BoardStats) ChecklistCompletion() float64 {
+	if s.ChecklistItems == 0 {
+		return 0
+	}
+	return float64(s.ChecklistItemsFinished) / float64(s.ChecklistItems)
+}
+
+type BoardSummary struct {
+	ID         string    `json:"_id"`
+	Title      string    `json:"title"`
+	Permission string    `json:"permission"`
+	Archived   bool      `json:"archived"`
+	ModifiedAt time.Time `json:"modifiedAt"`
+}
+
+// BoardType is the type of a board.
+// Wekan may report types that have no constant in this package.
+type BoardType string
+
+const (
+	BoardTypeBoard    BoardType = "board"
+	BoardTypeTemplate BoardType = "template-board"
+	// The board that holds the templates of a user.
+	BoardTypeTemplateContainer BoardType = "template-container"
+)
+
+// IsTemplate reports, whether the board is a template or holds templates.
+func (t BoardType) IsTemplate() bool {
+	return t == BoardTypeTemplate || t == BoardTypeTemplateContainer
+}
+
 type BoardLabel struct {
 	ID    string `json:"_id"`
 	Name  string `json:"name"`
@@ -280,6 +736,32 @@ type BoardAttachment struct {
 	SwimlaneID     string `json:"swimlaneId"`
 }
 
+// BoardAttachmentFilter filters board attachments. Empty fields match all attachments.
+type BoardAttachmentFilter struct {
+	// The MIME type of the attachment, e.g. "image/png".
+	// A type ending in "/", e.g. "image/", matches all subtypes.
+	AttachmentType string
+	SwimlaneID     string
+	ListID         string
+	CardID         string
+}
+
+func (f BoardAttachmentFilter) match(a BoardAttachment) bool {
+	if f.AttachmentType != "" {
+		if strings.HasSuffix(f.AttachmentType, "/") {
+			if !strings.HasPrefix(a.AttachmentType, f.AttachmentType) {
+				return false
+			}
+		} else if a.AttachmentType != f.AttachmentType {
+			return false
+		}
+	}
+
+	return (f.SwimlaneID == "" || a.SwimlaneID == f.SwimlaneID) &&
+		(f.ListID == "" || a.ListID == f.ListID) &&
+		(f.CardID == "" || a.CardID == f.CardID)
+}
+
 type NewBoardRequest struct {
 	// Required
 	Title string `json:"title"`
@@ -290,13 +772,48 @@ type NewBoardRequest struct {
 }
 
 type NewBoardOptions struct {
-	IsAdmin       bool   `json:"isAdmin"`
-	IsActive      bool   `json:"isActive"`
-	IsNoComments  bool   `json:"isNoComments"`
-	IsCommentOnly bool   `json:"isCommentOnly"`
-	IsWorker      bool   `json:"isWorker"`
-	Permission    string `json:"permission"`
-	Color         string `json:"color"`
+	IsAdmin       bool       `json:"isAdmin"`
+	IsActive      bool       `json:"isActive"`
+	IsNoComments  bool       `json:"isNoComments"`
+	IsCommentOnly bool       `json:"isCommentOnly"`
+	IsWorker      bool       `json:"isWorker"`
+	Permission    string     `json:"permission"`
+	Color         BoardColor `json:"color"`
+}
+
+// BoardColor is the color theme of a board.
+type BoardColor string
+
+const (
+	BoardColorBelize       BoardColor = "belize"
+	BoardColorNephritis    BoardColor = "nephritis"
+	BoardColorPomegranate  BoardColor = "pomegranate"
+	BoardColorPumpkin      BoardColor = "pumpkin"
+	BoardColorWisteria     BoardColor = "wisteria"
+	BoardColorModeratePink BoardColor = "moderatepink"
+	BoardColorStrongCyan   BoardColor = "strongcyan"
+	BoardColorLimeGreen    BoardColor = "limegreen"
+	BoardColorMidnight     BoardColor = "midnight"
+	BoardColorDark         BoardColor = "dark"
+	BoardColorRelax        BoardColor = "relax"
+	BoardColorCorteza      BoardColor = "corteza"
+	BoardColorClearBlue    BoardColor = "clearblue"
+	BoardColorNatural      BoardColor = "natural"
+	BoardColorModern       BoardColor = "modern"
+	BoardColorModernDark   BoardColor = "moderndark"
+)
+
+// Valid reports, whether c is one of the board colors supported by Wekan.
+func (c BoardColor) Valid() bool {
+	switch c {
+	case BoardColorBelize, BoardColorNephritis, BoardColorPomegranate, BoardColorPumpkin,
+		BoardColorWisteria, BoardColorModeratePink, BoardColorStrongCyan, BoardColorLimeGreen,
+		BoardColorMidnight, BoardColorDark, BoardColorRelax, BoardColorCorteza,
+		BoardColorClearBlue, BoardColorNatural, BoardColorModern, BoardColorModernDark:
+		return true
+	default:
+		return false
+	}
 }
 
 type NewBoardResponse struct {
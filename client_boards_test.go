@@ -0,0 +1,237 @@
+/**
+ * Copyright (c) 2023 Sebastian Borchers
+ *
+ * This software is released under the MIT License.
+ * https://opensource.org/licenses/MIT
+ */
+
+package wego
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestEnsureBoardLabelReturnsCreatedLabel(t *testing.T) {
+	var (
+		mx     sync.Mutex
+		board  = GetBoard{Title: "board", Labels: []BoardLabel{{ID: "existing", Name: "bug", Color: "red"}}}
+		adds   int
+		nextID int
+	)
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		mx.Lock()
+		defer mx.Unlock()
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/boards/b":
+			writeJSON(w, board)
+
+		case r.Method == http.MethodPut && r.URL.Path == "/api/boards/b/labels":
+			var req addBoardLabelRequest
+			json.NewDecoder(r.Body).Decode(&req)
+
+			adds++
+			nextID++
+			id := fmt.Sprintf("label-%d", nextID)
+			board.Labels = append(board.Labels, BoardLabel{ID: id, Name: req.Label.Name, Color: req.Label.Color})
+			writeJSON(w, id)
+
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	c := newTestClient(t, srv, Options{})
+	ctx := context.Background()
+
+	id, err := c.AddBoardLabel(ctx, "b", "feature", "green")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	label, err := c.EnsureBoardLabel(ctx, "b", "feature", "blue")
+	if err != nil {
+		t.Fatal(err)
+	} else if label.ID != id {
+		t.Fatalf("expected EnsureBoardLabel to return the created label '%s', got '%s'", id, label.ID)
+	} else if label.Color != "green" {
+		t.Fatalf("expected the color of the existing label to be kept, got '%s'", label.Color)
+	}
+
+	// A missing label is created once.
+	label, err = c.EnsureBoardLabel(ctx, "b", "docs", "blue")
+	if err != nil {
+		t.Fatal(err)
+	}
+	again, err := c.EnsureBoardLabel(ctx, "b", "docs", "blue")
+	if err != nil {
+		t.Fatal(err)
+	} else if again.ID != label.ID {
+		t.Fatalf("expected the same label id, got '%s' and '%s'", label.ID, again.ID)
+	} else if adds != 2 {
+		t.Fatalf("expected 2 labels to be created, got %d", adds)
+	}
+}
+
+func TestEnsureBoardLabelWithEmptyAddResponse(t *testing.T) {
+	var (
+		mx    sync.Mutex
+		board = GetBoard{Title: "board"}
+	)
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		mx.Lock()
+		defer mx.Unlock()
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/boards/b":
+			writeJSON(w, board)
+
+		case r.Method == http.MethodPut && r.URL.Path == "/api/boards/b/labels":
+			var req addBoardLabelRequest
+			json.NewDecoder(r.Body).Decode(&req)
+
+			// Wekan responds with an empty body, if the label exists already.
+			// Simulate a label, that has been created concurrently.
+			board.Labels = append(board.Labels, BoardLabel{ID: "concurrent", Name: req.Label.Name, Color: "red"})
+			w.WriteHeader(http.StatusOK)
+
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	c := newTestClient(t, srv, Options{})
+	ctx := context.Background()
+
+	id, err := c.AddBoardLabel(ctx, "b", "other", "green")
+	if err != nil {
+		t.Fatal(err)
+	} else if id != "" {
+		t.Fatalf("expected an empty label id, got '%s'", id)
+	}
+
+	label, err := c.EnsureBoardLabel(ctx, "b", "docs", "blue")
+	if err != nil {
+		t.Fatal(err)
+	} else if label.ID != "concurrent" || label.Color != "red" {
+		t.Fatalf("expected the existing label to be resolved, got %+v", label)
+	}
+}
+
+func TestDeleteBoardConflict(t *testing.T) {
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete || r.URL.Path != "/api/boards/b" {
+			http.NotFound(w, r)
+			return
+		}
+
+		w.Header().Set("Content-Type", mimeJSON)
+		w.WriteHeader(http.StatusConflict)
+		fmt.Fprint(w, `{"error":409,"reason":"board is the default board of a user"}`)
+	})
+	c := newTestClient(t, srv, Options{})
+
+	err := c.DeleteBoard(context.Background(), "b")
+	if !errors.Is(err, ErrConflict) {
+		t.Fatalf("expected an error matching ErrConflict, got: %v", err)
+	}
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected an *APIError, got: %T", err)
+	} else if apiErr.StatusCode != http.StatusConflict || apiErr.Reason != "board is the default board of a user" {
+		t.Fatalf("unexpected API error: %+v", apiErr)
+	}
+	if errors.Is(err, ErrNotFound) || errors.Is(err, ErrForbidden) {
+		t.Fatalf("conflict matches other sentinel errors: %v", err)
+	}
+}
+
+func TestGetBoardsFromUser(t *testing.T) {
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/api/users/u/boards" {
+			http.NotFound(w, r)
+			return
+		}
+
+		// The response shape of Wekan's get_boards_from_user route.
+		w.Header().Set("Content-Type", mimeJSON)
+		fmt.Fprint(w, `[{"_id":"b1","title":"Board 1"},{"_id":"b2","title":"Board 2"}]`)
+	})
+	c := newTestClient(t, srv, Options{})
+
+	want := []GetBoardFromUser{{ID: "b1", Title: "Board 1"}, {ID: "b2", Title: "Board 2"}}
+
+	boards, err := c.GetBoardsFromUser(context.Background(), "u")
+	if err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(boards, want) {
+		t.Fatalf("expected %+v, got %+v", want, boards)
+	}
+
+	boards, err = c.GetUserBoards(context.Background(), "u")
+	if err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(boards, want) {
+		t.Fatalf("GetUserBoards: expected %+v, got %+v", want, boards)
+	}
+}
+
+func TestNewBoardFromTemplate(t *testing.T) {
+	var (
+		mx        sync.Mutex
+		lists     []string
+		swimlanes []string
+	)
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		mx.Lock()
+		defer mx.Unlock()
+
+		switch r.Method + " " + r.URL.Path {
+		case "GET /api/boards/tmpl/lists":
+			// The server does not return the lists in their order.
+			writeJSON(w, []GetAllList{{ID: "l2", Title: "Doing", Sort: 1}, {ID: "l3", Title: "Done", Sort: 2}, {ID: "l1", Title: "Todo", Sort: 0}})
+		case "GET /api/boards/tmpl/swimlanes":
+			writeJSON(w, []GetAllSwimlane{{ID: "s1", Title: "Standard"}, {ID: "s2", Title: "Team"}})
+		case "POST /api/boards":
+			writeJSON(w, NewBoardResponse{ID: "nb", DefaultSwimlaneID: "ds"})
+		case "GET /api/boards/nb/swimlanes/ds":
+			// The default swimlane is named after the server's locale.
+			writeJSON(w, GetSwimlane{ID: "ds", Title: "Standard"})
+		case "POST /api/boards/nb/lists":
+			var req newListRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			lists = append(lists, req.Title)
+			writeJSON(w, NewListResponse{ID: req.Title})
+		case "POST /api/boards/nb/swimlanes":
+			var req newSwimlaneRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			swimlanes = append(swimlanes, req.Title)
+			writeJSON(w, NewSwimlaneResponse{ID: req.Title})
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	c := newTestClient(t, srv, Options{})
+
+	r, err := c.NewBoardFromTemplate(context.Background(), "tmpl", NewBoardRequest{Title: "board"})
+	if err != nil {
+		t.Fatal(err)
+	} else if r.ID != "nb" {
+		t.Fatalf("expected the board 'nb', got '%s'", r.ID)
+	}
+
+	mx.Lock()
+	defer mx.Unlock()
+
+	if want := []string{"Todo", "Doing", "Done"}; !reflect.DeepEqual(lists, want) {
+		t.Fatalf("expected the lists %q, got %q", want, lists)
+	} else if want := []string{"Team"}; !reflect.DeepEqual(swimlanes, want) {
+		t.Fatalf("expected the swimlanes %q, got %q", want, swimlanes)
+	}
+}
@@ -33,6 +33,9 @@ func (c *Client) GetAllComments(ctx context.Context, boardID, cardID string) (co
 
 // NewComment performs a new_comment request against the Wekan server.
 // See https://wekan.github.io/api/v5.13/#new_comment
+//
+// If data.IdempotencyKey is set and a comment has recently been created with the same key,
+// the previous response is returned without submitting the comment again.
 func (c *Client) NewComment(ctx context.Context, boardID, cardID string, data NewCommentRequest) (r NewCommentResponse, err error) {
 	endpoint := c.endpoint("boards", boardID, "cards", cardID, "comments")
 
@@ -41,7 +44,7 @@ func (c *Client) NewComment(ctx context.Context, boardID, cardID string, data Ne
 		return
 	}
 
-	err = c.doSimpleRequest(req, &r)
+	err = c.doIdempotentRequest(req, data.IdempotencyKey, &r)
 	if err != nil {
 		return
 	}
@@ -98,6 +101,10 @@ type GetAllComment struct {
 type NewCommentRequest struct {
 	AuthorID string `json:"authorId"`
 	Comment  string `json:"comment"`
+
+	// The key used to detect repeated submissions of the same comment.
+	// If empty, a random key is used.
+	IdempotencyKey string `json:"-"`
 }
 
 type NewCommentResponse struct {
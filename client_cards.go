@@ -9,8 +9,14 @@ package wego
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
+	"reflect"
+	"sort"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -32,6 +38,41 @@ func (c *Client) GetCardsByCustomField(ctx context.Context, boardID, customField
 	return
 }
 
+// GetCardsByCustomFieldFull performs a get_cards_by_custom_field request against the Wekan server
+// and fetches the full card for each match afterwards.
+// This costs an additional get_card request per match. The cards are fetched concurrently,
+// see Options.MaxConcurrency.
+//
+// The successfully fetched cards are always returned in the order of the matches.
+// If some cards failed, a *BatchError is returned, keyed by the index of the match.
+func (c *Client) GetCardsByCustomFieldFull(ctx context.Context, boardID, customField, customFieldValue string) (cards []GetCard, err error) {
+	matches, err := c.GetCardsByCustomField(ctx, boardID, customField, customFieldValue)
+	if err != nil {
+		return
+	}
+
+	var (
+		fetched = make([]GetCard, len(matches))
+		ok      = make([]bool, len(matches))
+	)
+	err = c.forEachConcurrent(ctx, len(matches), func(ctx context.Context, i int) (err error) {
+		fetched[i], err = c.GetCard(ctx, boardID, matches[i].ListID, matches[i].ID)
+		if err != nil {
+			return fmt.Errorf("failed to get card '%s': %w", matches[i].ID, err)
+		}
+		ok[i] = true
+		return nil
+	})
+
+	cards = make([]GetCard, 0, len(matches))
+	for i, card := range fetched {
+		if ok[i] {
+			cards = append(cards, card)
+		}
+	}
+	return
+}
+
 // GetAllCards performs a get_all_cards request against the Wekan server.
 // See https://wekan.github.io/api/v5.13/#get_all_cards
 func (c *Client) GetAllCards(ctx context.Context, boardID, listID string) (cards []GetAllCard, err error) {
@@ -50,8 +91,93 @@ func (c *Client) GetAllCards(ctx context.Context, boardID, listID string) (cards
 	return
 }
 
+// GetAllCardsSorted returns all cards of the given list in full, ordered as specified by opts.
+//
+// Note: The Wekan API does not support sort query parameters and the get_all_cards
+// response lacks most fields to sort by. Therefore, every card of the list is fetched with
+// GetCard, which costs one request per card in addition to the get_all_cards request.
+// The cards are fetched concurrently, see Options.MaxConcurrency, and sorted on the
+// client side. The sort is stable.
+func (c *Client) GetAllCardsSorted(ctx context.Context, boardID, listID string, opts CardSortOptions) (cards []GetCard, err error) {
+	less, err := opts.less()
+	if err != nil {
+		return
+	}
+
+	cards, err = c.getListCards(ctx, boardID, listID)
+	if err != nil {
+		return
+	}
+
+	sort.SliceStable(cards, func(i, j int) bool {
+		if opts.Order == SortDescending {
+			return less(cards[j], cards[i])
+		}
+		return less(cards[i], cards[j])
+	})
+	return
+}
+
+// GetArchivedCards returns all archived cards of the given list.
+// It performs a single board export request (see ExportJSON) and picks the archived cards
+// of the list from it, since get_all_cards omits them. The export grows with the board.
+func (c *Client) GetArchivedCards(ctx context.Context, boardID, listID string) (cards []GetCard, err error) {
+	boardJSON, err := c.ExportJSON(ctx, boardID)
+	if err != nil {
+		return
+	}
+
+	var export struct {
+		Cards []GetCard `json:"cards"`
+	}
+	err = json.Unmarshal(boardJSON, &export)
+	if err != nil {
+		err = fmt.Errorf("failed to unmarshal board export: %v", err)
+		return
+	}
+
+	for _, card := range export.Cards {
+		if card.Archived && card.ListID == listID {
+			cards = append(cards, card)
+		}
+	}
+
+	return
+}
+
+// GetCardsDueBefore returns all cards of the board that are due before the given time.
+// Cards without a due date are skipped.
+//
+// Note: Every card of the board is fetched to determine its due date. This costs a
+// get_all_lists request, a get_all_cards request per list and a get_card request per card.
+func (c *Client) GetCardsDueBefore(ctx context.Context, boardID string, before time.Time) (cards []GetCard, err error) {
+	lists, err := c.GetAllLists(ctx, boardID)
+	if err != nil {
+		return
+	}
+
+	for _, l := range lists {
+		var listCards []GetCard
+		listCards, err = c.getListCards(ctx, boardID, l.ID)
+		if err != nil {
+			return
+		}
+
+		for _, card := range listCards {
+			if due, ok := card.DueTime(); ok && due.Before(before) {
+				cards = append(cards, card)
+			}
+		}
+	}
+
+	return
+}
+
 // NewCard performs a new_card request against the Wekan server.
 // See https://wekan.github.io/api/v5.13/#new_card
+//
+// If request.IdempotencyKey is set and a card has recently been created with the same key,
+// the previous response is returned without submitting the card again.
 func (c *Client) NewCard(ctx context.Context, boardID, listID string, request NewCardRequest) (r NewCardResponse, err error) {
 	var endpoint = c.endpoint("boards", boardID, "lists", listID, "cards")
 
@@ -60,9 +186,178 @@ func (c *Client) NewCard(ctx context.Context, boardID, listID string, request Ne
 		return
 	}
 
-	err = c.doSimpleRequest(req, &r)
+	err = c.doIdempotentRequest(req, request.IdempotencyKey, &r)
+	if err != nil {
+		return
+	}
+
+	// Fetch the card, unless the server already sent its number.
+	if request.FetchCardNumber && r.CardNumber == 0 && r.ID != "" {
+		var card GetCard
+		card, err = c.GetCard(ctx, boardID, listID, r.ID)
+		if err != nil {
+			err = fmt.Errorf("failed to get card number of created card '%s': %w", r.ID, err)
+			return
+		}
+		r.CardNumber = card.CardNumber
+	}
+
+	return
+}
+
+// QuickAddCard creates a new card with the given title on the first list and first swimlane
+// of the board, authored by the current user.
+// It performs a get_all_lists, get_all_swimlanes and new_card request.
+//
+// Note: The card is placed on whatever list and swimlane the server returns first.
+// Use NewCard, if the card must be placed precisely.
+//
+// Returns ErrNotFound, if the board has no list or no swimlane.
+func (c *Client) QuickAddCard(ctx context.Context, boardID, title string) (r NewCardResponse, err error) {
+	lists, err := c.GetAllLists(ctx, boardID)
+	if err != nil {
+		return
+	} else if len(lists) == 0 {
+		err = fmt.Errorf("no list on board: %w", ErrNotFound)
+		return
+	}
+
+	swimlanes, err := c.GetAllSwimlanes(ctx, boardID)
 	if err != nil {
 		return
+	} else if len(swimlanes) == 0 {
+		err = fmt.Errorf("no swimlane on board: %w", ErrNotFound)
+		return
+	}
+
+	return c.NewCard(ctx, boardID, lists[0].ID, NewCardRequest{
+		AuthorID:   c.GetCurrentUserID(),
+		Title:      title,
+		SwimlaneID: swimlanes[0].ID,
+	})
+}
+
+// GetCardTemplates returns all card templates of the given board.
+// Usually, the templates are stored on the templates board of a user,
+// see UserProfile.TemplatesBoardID.
+//
+// Note: Every card of the board is fetched to determine its type. This costs a
+// get_all_lists request, a get_all_cards request per list and a get_card request per card.
+func (c *Client) GetCardTemplates(ctx context.Context, boardID string) (cards []GetCard, err error) {
+	lists, err := c.GetAllLists(ctx, boardID)
+	if err != nil {
+		return
+	}
+
+	for _, l := range lists {
+		var listCards []GetAllCard
+		listCards, err = c.GetAllCards(ctx, boardID, l.ID)
+		if err != nil {
+			return
+		}
+
+		for _, lc := range listCards {
+			var card GetCard
+			card, err = c.GetCard(ctx, boardID, l.ID, lc.ID)
+			if err != nil {
+				err = fmt.Errorf("failed to get card '%s': %w", lc.ID, err)
+				return
+			}
+
+			if card.Type.IsTemplate() {
+				cards = append(cards, card)
+			}
+		}
+	}
+
+	return
+}
+
+// NewCardFromTemplate creates a new card on the given list from a card template of the
+// current user's templates board. The title, description, members, assignees and checklists
+// of the template are copied, afterwards the overrides are applied.
+// If overrides.SwimlaneID is not set, the card is placed on the first swimlane of the board.
+// It performs a get_user, get_card_by_id, new_card and get_all_checklists request,
+// a get_checklist and new_checklist request per checklist and, if overrides are set,
+// an edit_card request. Without overrides.SwimlaneID, a get_all_swimlanes request is added.
+//
+// Returns ErrNotFound, if the template could not be found or the card is no template.
+func (c *Client) NewCardFromTemplate(ctx context.Context, boardID, listID, templateCardID string, overrides EditCardOptions) (r NewCardResponse, err error) {
+	user, err := c.GetCurrentUser(ctx)
+	if err != nil {
+		return
+	}
+
+	templatesBoardID := user.Profile.TemplatesBoardID
+	tmpl, err := c.GetCardByID(ctx, templatesBoardID, templateCardID)
+	if err != nil {
+		err = fmt.Errorf("card template '%s': %w", templateCardID, err)
+		return
+	} else if !tmpl.Type.IsTemplate() {
+		err = fmt.Errorf("card template '%s': card is no template: %w", templateCardID, ErrNotFound)
+		return
+	}
+
+	// Determine the swimlane of the new card.
+	var swimlaneID string
+	if overrides.SwimlaneID != nil {
+		swimlaneID = *overrides.SwimlaneID
+	} else {
+		var swimlanes []GetAllSwimlane
+		swimlanes, err = c.GetAllSwimlanes(ctx, boardID)
+		if err != nil {
+			return
+		} else if len(swimlanes) == 0 {
+			err = fmt.Errorf("no swimlane on board: %w", ErrNotFound)
+			return
+		}
+		swimlaneID = swimlanes[0].ID
+	}
+
+	r, err = c.NewCard(ctx, boardID, listID, NewCardRequest{
+		AuthorID:    c.GetCurrentUserID(),
+		Title:       tmpl.Title,
+		Description: tmpl.Description,
+		SwimlaneID:  swimlaneID,
+		NewCardOptions: NewCardOptions{
+			MemberIDs: tmpl.Members,
+			Assignees: tmpl.Assignees,
+		},
+	})
+	if err != nil {
+		return
+	}
+
+	// Copy the checklists.
+	checklists, err := c.GetAllChecklists(ctx, templatesBoardID, tmpl.ID)
+	if err != nil {
+		return
+	}
+	for _, cl := range checklists {
+		var checklist GetChecklist
+		checklist, err = c.GetChecklist(ctx, templatesBoardID, tmpl.ID, cl.ID)
+		if err != nil {
+			return
+		}
+
+		items := make([]string, len(checklist.Items))
+		for i, item := range checklist.Items {
+			items[i] = item.Title
+		}
+
+		_, err = c.NewChecklist(ctx, boardID, r.ID, NewChecklistRequest{Title: checklist.Title, Items: items})
+		if err != nil {
+			err = fmt.Errorf("failed to copy checklist '%s': %w", checklist.Title, err)
+			return
+		}
+	}
+
+	// Apply the overrides.
+	if !reflect.DeepEqual(overrides, EditCardOptions{}) {
+		_, err = c.EditCard(ctx, boardID, listID, r.ID, overrides)
+		if err != nil {
+			return
+		}
 	}
 
 	return
@@ -91,11 +386,156 @@ func (c *Client) GetCard(ctx context.Context, boardID, listID, cardID string) (c
 	return
 }
 
+// ClearCardLabels removes all labels from the card.
+// It performs a single edit_card request.
+func (c *Client) ClearCardLabels(ctx context.Context, boardID, listID, cardID string) (err error) {
+	_, err = c.EditCardWith(ctx, boardID, listID, cardID, CardClear(CardFieldLabelIDs))
+	return
+}
+
+// WaitForCard polls the card every interval until cond reports true for it and returns
+// the card. This is useful to wait for changes that are applied asynchronously.
+// If ctx is done first, the last fetched card is returned along with the error of ctx.
+// Errors of GetCard abort the wait, except for ErrNotFound, since the card may not
+// have been created yet.
+// It performs a get_card request per poll.
+func (c *Client) WaitForCard(ctx context.Context, boardID, listID, cardID string, cond func(GetCard) bool, interval time.Duration) (card GetCard, err error) {
+	if interval <= 0 {
+		err = fmt.Errorf("invalid poll interval '%v': must be positive", interval)
+		return
+	}
+
+	t := time.NewTicker(interval)
+	defer t.Stop()
+
+	for {
+		var cur GetCard
+		cur, err = c.GetCard(ctx, boardID, listID, cardID)
+		if err == nil {
+			card = cur
+			if cond(card) {
+				return
+			}
+		} else if ctx.Err() != nil {
+			err = ctx.Err()
+			return
+		} else if !errors.Is(err, ErrNotFound) {
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			err = ctx.Err()
+			return
+		case <-t.C:
+		}
+	}
+}
+
+// EditCardWith performs a edit_card request against the Wekan server,
+// with the options built from the given functional options.
+// Only the fields set by the options are sent, see EditCard.
+func (c *Client) EditCardWith(ctx context.Context, boardID, listID, cardID string, opts ...EditCardOption) (r EditCardResponse, err error) {
+	var o EditCardOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return c.EditCard(ctx, boardID, listID, cardID, o)
+}
+
+// GetCardWithResolvedMembers performs a get_card request against the Wekan server and
+// resolves the ids of the card's members and assignees to users.
+// The users are fetched concurrently.
+// It performs a get_user request per distinct member and assignee.
+//
+// Returns ErrNotFound, if the card could not be found.
+func (c *Client) GetCardWithResolvedMembers(ctx context.Context, boardID, listID, cardID string) (r CardWithMembers, err error) {
+	r.Card, err = c.GetCard(ctx, boardID, listID, cardID)
+	if err != nil {
+		return
+	}
+
+	// Fetch every user only once.
+	var userIDs []string
+	seen := make(map[string]struct{})
+	for _, id := range append(append([]string{}, r.Card.Members...), r.Card.Assignees...) {
+		if _, ok := seen[id]; !ok {
+			seen[id] = struct{}{}
+			userIDs = append(userIDs, id)
+		}
+	}
+
+	var (
+		mx    sync.Mutex
+		users = make(map[string]CardMember, len(userIDs))
+	)
+	err = c.forEachConcurrent(ctx, len(userIDs), func(ctx context.Context, i int) error {
+		u, err := c.GetUser(ctx, userIDs[i])
+		if err != nil {
+			return fmt.Errorf("failed to get user '%s': %w", userIDs[i], err)
+		}
+
+		mx.Lock()
+		users[userIDs[i]] = CardMember{ID: userIDs[i], Username: u.Username, Fullname: u.Profile.Fullname}
+		mx.Unlock()
+		return nil
+	})
+	if err != nil {
+		return
+	}
+
+	for _, id := range r.Card.Members {
+		r.Members = append(r.Members, users[id])
+	}
+	for _, id := range r.Card.Assignees {
+		r.Assignees = append(r.Assignees, users[id])
+	}
+
+	return
+}
+
+// GetCardByID performs a get_card_by_id request against the Wekan server.
+// Unlike GetCard, it does not require the id of the card's list, since it uses the
+// list-independent card route, which is also used by DeleteCard.
+// See https://wekan.github.io/api/v6.97/#get_card_by_id
+//
+// Returns ErrNotFound, if the card could not be found.
+func (c *Client) GetCardByID(ctx context.Context, boardID, cardID string) (card GetCard, err error) {
+	var endpoint = c.endpoint("boards", boardID, "cards", cardID)
+
+	req, err := c.newAuthenticatedGETRequest(ctx, endpoint)
+	if err != nil {
+		return
+	}
+
+	err = c.doSimpleRequest(req, &card)
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			err = ErrNotFound
+		}
+		return
+	}
+
+	return
+}
+
+// CardExists reports, whether the card exists.
+// It performs a single get_card request.
+func (c *Client) CardExists(ctx context.Context, boardID, listID, cardID string) (bool, error) {
+	return c.exists(ctx, c.endpoint("boards", boardID, "lists", listID, "cards", cardID))
+}
+
 // EditCard performs a edit_card request against the Wekan server.
 // See https://wekan.github.io/api/v5.13/#edit_card
 func (c *Client) EditCard(ctx context.Context, boardID, listID, cardID string, opts EditCardOptions) (r EditCardResponse, err error) {
 	endpoint := c.endpoint("boards", boardID, "lists", listID, "cards", cardID)
 
+	err = validateCardCustomFields(opts.CustomFields)
+	if err != nil {
+		return
+	}
+
 	req, err := c.newAuthenticatedPUTRequest(ctx, endpoint, opts)
 	if err != nil {
 		return
@@ -109,6 +549,126 @@ func (c *Client) EditCard(ctx context.Context, boardID, listID, cardID string, o
 	return
 }
 
+// MoveCards moves the given cards of the board to the destination list and swimlane.
+// If destSwimlaneID is empty, the cards stay in their swimlanes.
+// The destination is validated once, then the cards are moved concurrently,
+// see Options.MaxConcurrency.
+// It performs a get_list request, a get_swimlane request, if destSwimlaneID is set, and
+// a get_card_by_id and edit_card request per card.
+//
+// Returns the number of moved cards. If some cards could not be moved, a *BatchError is
+// returned, keyed by the index of the card in cardIDs.
+// Returns ErrNotFound, if the destination list or swimlane does not exist.
+func (c *Client) MoveCards(ctx context.Context, boardID string, cardIDs []string, destListID, destSwimlaneID string) (moved int, err error) {
+	ok, err := c.ListExists(ctx, boardID, destListID)
+	if err != nil {
+		return
+	} else if !ok {
+		err = fmt.Errorf("destination list '%s': %w", destListID, ErrNotFound)
+		return
+	}
+
+	opts := EditCardOptions{ListID: &destListID}
+	if destSwimlaneID != "" {
+		_, err = c.GetSwimlane(ctx, boardID, destSwimlaneID)
+		if err != nil {
+			err = fmt.Errorf("destination swimlane '%s': %w", destSwimlaneID, err)
+			return
+		}
+		opts.SwimlaneID = &destSwimlaneID
+	}
+
+	var n atomic.Int64
+	err = c.forEachConcurrent(ctx, len(cardIDs), func(ctx context.Context, i int) error {
+		// The edit_card route requires the current list of the card.
+		card, err := c.GetCardByID(ctx, boardID, cardIDs[i])
+		if err != nil {
+			return fmt.Errorf("card '%s': %w", cardIDs[i], err)
+		}
+
+		_, err = c.EditCard(ctx, boardID, card.ListID, cardIDs[i], opts)
+		if err != nil {
+			return fmt.Errorf("card '%s': %w", cardIDs[i], err)
+		}
+
+		n.Add(1)
+		return nil
+	})
+	moved = int(n.Load())
+	return
+}
+
+// MoveCardToTop moves a card to the top of its list, by giving it a sort value
+// lower than the one of every other card in the list.
+// It performs a get_all_cards request and an edit_card request.
+//
+// Note: The sort values are taken from get_all_cards. Wekan versions, that do not send
+// them there, require an additional get_card request per card of the list.
+func (c *Client) MoveCardToTop(ctx context.Context, boardID, listID, cardID string) (err error) {
+	return c.moveCardToEdge(ctx, boardID, listID, cardID, true)
+}
+
+// MoveCardToBottom moves a card to the bottom of its list, by giving it a sort value
+// higher than the one of every other card in the list.
+// It performs a get_all_cards request and an edit_card request.
+//
+// Note: The sort values are obtained like by MoveCardToTop.
+func (c *Client) MoveCardToBottom(ctx context.Context, boardID, listID, cardID string) (err error) {
+	return c.moveCardToEdge(ctx, boardID, listID, cardID, false)
+}
+
+// RebalanceListSort reassigns the sort values of all cards of a list, so that they
+// are evenly spaced integers starting at 0, while preserving the current order.
+// Only cards whose sort value changes are updated.
+// Use it to repair lists, whose fractional sort values collide after many insertions.
+// It performs a get_all_cards request and an edit_card request per changed card.
+// The sort values are obtained like by MoveCardToTop.
+//
+// If some cards could not be updated, a *BatchError is returned, keyed by the position
+// of the card in the rebalanced order.
+func (c *Client) RebalanceListSort(ctx context.Context, boardID, listID string) (err error) {
+	cards, err := c.getListCardSorts(ctx, boardID, listID)
+	if err != nil {
+		return
+	}
+
+	sort.SliceStable(cards, func(i, j int) bool {
+		return cards[i].Sort < cards[j].Sort
+	})
+
+	return c.forEachConcurrent(ctx, len(cards), func(ctx context.Context, i int) error {
+		newSort := float64(i)
+		if cards[i].Sort == newSort {
+			return nil
+		}
+
+		_, err := c.EditCard(ctx, boardID, listID, cards[i].ID, EditCardOptions{Sort: &newSort})
+		if err != nil {
+			return fmt.Errorf("failed to update card '%s': %w", cards[i].ID, err)
+		}
+		return nil
+	})
+}
+
+// SetCardDates sets the dates of a card that are not nil in dates.
+// It performs a single edit_card request.
+//
+// Returns an error, if the due date is before the start date or the end date is before the due date.
+func (c *Client) SetCardDates(ctx context.Context, boardID, listID, cardID string, dates CardDates) (err error) {
+	err = dates.validate()
+	if err != nil {
+		return
+	}
+
+	_, err = c.EditCard(ctx, boardID, listID, cardID, EditCardOptions{
+		ReceivedAt: dates.ReceivedAt,
+		StartAt:    dates.StartAt,
+		DueAt:      dates.DueAt,
+		EndAt:      dates.EndAt,
+	})
+	return
+}
+
 // DeleteCard performs a delete_card request against the Wekan server.
 // See https://wekan.github.io/api/v5.13/#delete_card
 func (c *Client) DeleteCard(ctx context.Context, boardID, cardID string) (err error) {
@@ -140,14 +700,217 @@ func (c *Client) GetSwimlaneCards(ctx context.Context, boardID, swimlaneID strin
 	return
 }
 
+// IterateSwimlaneCards performs a get_swimlane_cards request against the Wekan server
+// and passes the cards to fn one at a time, while the response is being decoded.
+// The iteration stops early, if fn returns false.
+// This avoids holding all cards of large swimlanes in memory at once.
+//
+// Options.MaxResponseBytes does not apply.
+//
+// Note: The Wekan API does not support pagination for this route, the server
+// always sends all cards of the swimlane.
+func (c *Client) IterateSwimlaneCards(ctx context.Context, boardID, swimlaneID string, fn func(card GetSwimlaneCard) bool) (err error) {
+	var endpoint = c.endpoint("boards", boardID, "swimlanes", swimlaneID, "cards")
+
+	req, err := c.newAuthenticatedGETRequest(ctx, endpoint)
+	if err != nil {
+		return
+	}
+
+	return c.doStreamRequest(req, func(body io.Reader) error {
+		return decodeJSONArray(body, fn)
+	})
+}
+
+//################//
+//### Internal ###//
+//################//
+
+// getListCards fetches every card of the list in full.
+// The get_all_cards response lacks most card fields, therefore the cards are fetched
+// concurrently one by one.
+func (c *Client) getListCards(ctx context.Context, boardID, listID string) (cards []GetCard, err error) {
+	all, err := c.GetAllCards(ctx, boardID, listID)
+	if err != nil {
+		return
+	}
+
+	cards = make([]GetCard, len(all))
+	err = c.forEachConcurrent(ctx, len(all), func(ctx context.Context, i int) (err error) {
+		cards[i], err = c.GetCard(ctx, boardID, listID, all[i].ID)
+		if err != nil {
+			return fmt.Errorf("failed to get card '%s': %w", all[i].ID, err)
+		}
+		return nil
+	})
+	return
+}
+
+// getListCardSorts returns the cards of the list with their sort values.
+// If the get_all_cards response lacks the sort values, every card is fetched with GetCard
+// concurrently to obtain them.
+func (c *Client) getListCardSorts(ctx context.Context, boardID, listID string) (cards []GetAllCard, err error) {
+	cards, err = c.GetAllCards(ctx, boardID, listID)
+	if err != nil || hasCardSortValues(cards) {
+		return
+	}
+
+	err = c.forEachConcurrent(ctx, len(cards), func(ctx context.Context, i int) error {
+		card, err := c.GetCard(ctx, boardID, listID, cards[i].ID)
+		if err != nil {
+			return fmt.Errorf("failed to get card '%s': %w", cards[i].ID, err)
+		}
+
+		cards[i].Sort = card.Sort
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return
+}
+
+// hasCardSortValues reports, whether the server sent the sort values of the cards.
+// Since Wekan numbers the cards from 0, the values are missing, if all of them are 0.
+func hasCardSortValues(cards []GetAllCard) bool {
+	for _, card := range cards {
+		if card.Sort != 0 {
+			return true
+		}
+	}
+	return len(cards) <= 1
+}
+
+// moveCardToEdge moves the card to the top or the bottom of the list.
+func (c *Client) moveCardToEdge(ctx context.Context, boardID, listID, cardID string, top bool) (err error) {
+	cards, err := c.getListCardSorts(ctx, boardID, listID)
+	if err != nil {
+		return
+	}
+
+	var (
+		edge  float64
+		found bool
+	)
+	for _, card := range cards {
+		if card.ID == cardID {
+			continue
+		}
+
+		if !found || (top && card.Sort < edge) || (!top && card.Sort > edge) {
+			edge = card.Sort
+			found = true
+		}
+	}
+
+	// Place the card before or after the edge card.
+	if top {
+		edge--
+	} else {
+		edge++
+	}
+
+	_, err = c.EditCard(ctx, boardID, listID, cardID, EditCardOptions{Sort: &edge})
+	return
+}
+
+// validateCardCustomFields ensures that the values of all fields can be marshaled to JSON.
+// This catches unsupported value types early with a message naming the offending field,
+// including values nested in maps, slices or structs.
+func validateCardCustomFields(fields []CardCustomField) error {
+	for i, f := range fields {
+		if f.Value == nil {
+			continue
+		}
+
+		_, err := json.Marshal(f.Value)
+		if err != nil {
+			return fmt.Errorf("customFields[%d].value: unsupported custom field value of type %T of field '%s': %w", i, f.Value, f.ID, err)
+		}
+	}
+
+	return nil
+}
+
 //#############//
 //### Types ###//
 //#############//
 
+// SortOrder is the order of sorted results.
+type SortOrder int
+
+const (
+	SortAscending SortOrder = iota
+	SortDescending
+)
+
+// CardSortBy is the field cards are sorted by.
+type CardSortBy string
+
+const (
+	// CardSortBySort sorts the cards by their position in the list, which is the
+	// order shown by Wekan.
+	CardSortBySort       CardSortBy = "sort"
+	CardSortByTitle      CardSortBy = "title"
+	CardSortByCreatedAt  CardSortBy = "createdAt"
+	CardSortByModifiedAt CardSortBy = "modifiedAt"
+	CardSortByDueAt      CardSortBy = "dueAt"
+)
+
+type CardSortOptions struct {
+	// The field to sort by. Defaults to CardSortBySort.
+	By CardSortBy
+	// Defaults to SortAscending.
+	Order SortOrder
+}
+
+// less returns the comparison of cards for the sort field of o, in ascending order.
+func (o CardSortOptions) less() (func(a, b GetCard) bool, error) {
+	switch o.By {
+	case "", CardSortBySort:
+		return func(a, b GetCard) bool { return a.Sort < b.Sort }, nil
+	case CardSortByTitle:
+		return func(a, b GetCard) bool { return a.Title < b.Title }, nil
+	case CardSortByCreatedAt:
+		// The time stamps are ISO 8601 strings, which sort chronologically.
+		return func(a, b GetCard) bool { return a.CreatedAt < b.CreatedAt }, nil
+	case CardSortByModifiedAt:
+		return func(a, b GetCard) bool { return a.ModifiedAt < b.ModifiedAt }, nil
+	case CardSortByDueAt:
+		return func(a, b GetCard) bool { return a.DueAt < b.DueAt }, nil
+	default:
+		return nil, fmt.Errorf("unknown card sort field '%s'", o.By)
+	}
+}
+
+// CardType is the type of a card.
+// Wekan may report types that have no constant in this package.
+type CardType string
+
+const (
+	CardTypeCard        CardType = "cardType-card"
+	CardTypeLinkedCard  CardType = "cardType-linkedCard"
+	CardTypeLinkedBoard CardType = "cardType-linkedBoard"
+	CardTypeTemplate    CardType = "template-card"
+)
+
+// IsTemplate reports, whether the card is a template.
+func (t CardType) IsTemplate() bool {
+	return t == CardTypeTemplate
+}
+
+// GetAllCard is a card as returned by get_all_cards.
+// Depending on the Wekan version, the server omits the label ids, members, due date and
+// sort value. They are empty in this case, use GetCard to obtain them.
 type GetAllCard struct {
-	ID          string `json:"_id"`
-	Title       string `json:"title"`
-	Description string `json:"description"`
+	ID          string   `json:"_id"`
+	Title       string   `json:"title"`
+	Description string   `json:"description"`
+	LabelIds    []string `json:"labelIds"`
+	Members     []string `json:"members"`
+	DueAt       string   `json:"dueAt"`
+	// The position of the card in its list.
+	Sort float64 `json:"sort"`
 }
 
 type GetCardByCustomField struct {
@@ -179,13 +942,25 @@ type NewCardRequest struct {
 type NewCardOptions struct {
 	MemberIDs []string `json:"members,omitempty"`
 	Assignees []string `json:"assignees,omitempty"`
+
+	// The key used to detect repeated submissions of the same card.
+	// If empty, a random key is used.
+	IdempotencyKey string `json:"-"`
+
+	// If true, the created card is fetched to fill NewCardResponse.CardNumber,
+	// which costs an additional request.
+	FetchCardNumber bool `json:"-"`
 }
 
 type NewCardResponse struct {
 	ID string `json:"_id"`
+	// The number of the card on its board.
+	// Only set, if requested with NewCardOptions.FetchCardNumber.
+	CardNumber int `json:"cardNumber"`
 }
 
 type GetCard struct {
+	ID               string            `json:"_id"`
 	Title            string            `json:"title"`
 	Archived         bool              `json:"archived"`
 	ArchivedAt       string            `json:"archivedAt"`
@@ -214,7 +989,7 @@ type GetCard struct {
 	UserID           string            `json:"userId"`
 	Sort             float64           `json:"sort"`
 	SubtaskSort      int               `json:"subtaskSort"`
-	Type             string            `json:"type"`
+	Type             CardType          `json:"type"`
 	LinkedID         string            `json:"linkedId"`
 	Vote             Vote              `json:"vote"`
 	Poker            Poker             `json:"poker"`
@@ -224,6 +999,61 @@ type GetCard struct {
 	CardNumber       int               `json:"cardNumber"`
 }
 
+// DueTime returns the parsed due date of the card and whether it has one.
+func (c GetCard) DueTime() (time.Time, bool) {
+	if c.DueAt == "" {
+		return time.Time{}, false
+	}
+
+	t, err := time.Parse(time.RFC3339, c.DueAt)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+// GanttLinks returns the gantt links of the card, zipped from its parallel gantt arrays.
+// Surplus elements of arrays of unequal length are ignored.
+func (c GetCard) GanttLinks() []GanttLink {
+	n := len(c.TargetIDGantt)
+	if len(c.LinkTypeGantt) < n {
+		n = len(c.LinkTypeGantt)
+	}
+	if len(c.LinkIDGantt) < n {
+		n = len(c.LinkIDGantt)
+	}
+
+	links := make([]GanttLink, n)
+	for i := range links {
+		links[i] = GanttLink{
+			TargetID: c.TargetIDGantt[i],
+			LinkType: c.LinkTypeGantt[i],
+			LinkID:   c.LinkIDGantt[i],
+		}
+	}
+	return links
+}
+
+// GanttLink is a dependency of a card on another card in the gantt view.
+type GanttLink struct {
+	TargetID string
+	LinkType int
+	LinkID   string
+}
+
+// CardWithMembers is a card with its members and assignees resolved to users.
+type CardWithMembers struct {
+	Card      GetCard
+	Members   []CardMember
+	Assignees []CardMember
+}
+
+type CardMember struct {
+	ID       string
+	Username string
+	Fullname string
+}
+
 type CardCustomField struct {
 	ID    string `json:"_id"`
 	Value any    `json:"value"`
@@ -255,29 +1085,223 @@ type Poker struct {
 	Estimation           int      `json:"estimation,omitempty"`
 }
 
+// EditCardOptions are the options of an edit_card request.
+// Nil fields are omitted and remain unchanged on the server.
 type EditCardOptions struct {
-	Title        string            `json:"title,omitempty"`
-	Sort         string            `json:"sort,omitempty"`
-	ParentID     string            `json:"parentId,omitempty"`
-	Description  string            `json:"description,omitempty"`
-	Color        string            `json:"color,omitempty"`
+	Title        *string           `json:"title,omitempty"`
+	Sort         *float64          `json:"sort,omitempty"`
+	ParentID     *string           `json:"parentId,omitempty"`
+	Description  *string           `json:"description,omitempty"`
+	Color        *string           `json:"color,omitempty"`
 	Vote         *Vote             `json:"vote,omitempty"`
 	Poker        *Poker            `json:"poker,omitempty"`
 	LabelIDs     []string          `json:"labelIds,omitempty"`
-	RequestedBy  string            `json:"requestedBy,omitempty"`
-	AssignedBy   string            `json:"assignedBy,omitempty"`
+	RequestedBy  *string           `json:"requestedBy,omitempty"`
+	AssignedBy   *string           `json:"assignedBy,omitempty"`
 	ReceivedAt   *time.Time        `json:"receivedAt,omitempty"`
 	StartAt      *time.Time        `json:"startAt,omitempty"`
 	DueAt        *time.Time        `json:"dueAt,omitempty"`
 	EndAt        *time.Time        `json:"endAt,omitempty"`
-	SpentTime    string            `json:"spentTime,omitempty"`
-	IsOverTime   bool              `json:"isOverTime,omitempty"`
+	SpentTime    *string           `json:"spentTime,omitempty"`
+	IsOverTime   *bool             `json:"isOverTime,omitempty"`
 	CustomFields []CardCustomField `json:"customFields,omitempty"`
 	Members      []string          `json:"members,omitempty"`
 	Assignees    []string          `json:"assignees,omitempty"`
-	SwimlaneID   string            `json:"swimlaneId,omitempty"`
-	ListID       string            `json:"listId,omitempty"`
-	AuthorID     string            `json:"authorId,omitempty"`
+	SwimlaneID   *string           `json:"swimlaneId,omitempty"`
+	ListID       *string           `json:"listId,omitempty"`
+	AuthorID     *string           `json:"authorId,omitempty"`
+
+	// The gantt links of the card. If not nil, they replace all existing links.
+	// They are sent as the parallel arrays Wekan stores them in.
+	GanttLinks []GanttLink `json:"-"`
+
+	// The fields that should be cleared, i.e. sent as null, or as empty array for
+	// array fields like the labels.
+	// See the CardField constants for the fields that can be cleared.
+	// A cleared field must not be set at the same time.
+	ClearFields []string `json:"-"`
+}
+
+// EditCardOption sets a single field of EditCardOptions, see EditCardWith.
+type EditCardOption func(o *EditCardOptions)
+
+func CardTitle(title string) EditCardOption {
+	return func(o *EditCardOptions) { o.Title = &title }
+}
+
+func CardDescription(description string) EditCardOption {
+	return func(o *EditCardOptions) { o.Description = &description }
+}
+
+func CardColor(color string) EditCardOption {
+	return func(o *EditCardOptions) { o.Color = &color }
+}
+
+func CardSort(sort float64) EditCardOption {
+	return func(o *EditCardOptions) { o.Sort = &sort }
+}
+
+func CardParent(parentID string) EditCardOption {
+	return func(o *EditCardOptions) { o.ParentID = &parentID }
+}
+
+func CardLabels(labelIDs ...string) EditCardOption {
+	return func(o *EditCardOptions) { o.LabelIDs = labelIDs }
+}
+
+func CardMembers(memberIDs ...string) EditCardOption {
+	return func(o *EditCardOptions) { o.Members = memberIDs }
+}
+
+func CardAssignees(assigneeIDs ...string) EditCardOption {
+	return func(o *EditCardOptions) { o.Assignees = assigneeIDs }
+}
+
+func CardRequestedBy(requestedBy string) EditCardOption {
+	return func(o *EditCardOptions) { o.RequestedBy = &requestedBy }
+}
+
+func CardAssignedBy(assignedBy string) EditCardOption {
+	return func(o *EditCardOptions) { o.AssignedBy = &assignedBy }
+}
+
+func CardReceived(t time.Time) EditCardOption {
+	return func(o *EditCardOptions) { o.ReceivedAt = &t }
+}
+
+func CardStart(t time.Time) EditCardOption {
+	return func(o *EditCardOptions) { o.StartAt = &t }
+}
+
+func CardDue(t time.Time) EditCardOption {
+	return func(o *EditCardOptions) { o.DueAt = &t }
+}
+
+func CardEnd(t time.Time) EditCardOption {
+	return func(o *EditCardOptions) { o.EndAt = &t }
+}
+
+func CardCustomFields(fields ...CardCustomField) EditCardOption {
+	return func(o *EditCardOptions) { o.CustomFields = fields }
+}
+
+// CardMove moves the card to the given list and swimlane.
+func CardMove(listID, swimlaneID string) EditCardOption {
+	return func(o *EditCardOptions) {
+		o.ListID = &listID
+		o.SwimlaneID = &swimlaneID
+	}
+}
+
+// CardClear clears the given fields, see EditCardOptions.ClearFields.
+func CardClear(fields ...string) EditCardOption {
+	return func(o *EditCardOptions) { o.ClearFields = append(o.ClearFields, fields...) }
+}
+
+// The fields of a card that can be cleared with EditCardOptions.ClearFields.
+const (
+	CardFieldParentID     = "parentId"
+	CardFieldDescription  = "description"
+	CardFieldColor        = "color"
+	CardFieldVote         = "vote"
+	CardFieldPoker        = "poker"
+	CardFieldLabelIDs     = "labelIds"
+	CardFieldRequestedBy  = "requestedBy"
+	CardFieldAssignedBy   = "assignedBy"
+	CardFieldReceivedAt   = "receivedAt"
+	CardFieldStartAt      = "startAt"
+	CardFieldDueAt        = "dueAt"
+	CardFieldEndAt        = "endAt"
+	CardFieldSpentTime    = "spentTime"
+	CardFieldCustomFields = "customFields"
+	CardFieldMembers      = "members"
+	CardFieldAssignees    = "assignees"
+)
+
+// clearableCardFields maps the fields that can be cleared to the value that clears them.
+// Wekan ignores falsy values for array fields, they are cleared with an empty array instead.
+var clearableCardFields = map[string]json.RawMessage{
+	CardFieldParentID:     json.RawMessage("null"),
+	CardFieldDescription:  json.RawMessage("null"),
+	CardFieldColor:        json.RawMessage("null"),
+	CardFieldVote:         json.RawMessage("null"),
+	CardFieldPoker:        json.RawMessage("null"),
+	CardFieldLabelIDs:     json.RawMessage("[]"),
+	CardFieldRequestedBy:  json.RawMessage("null"),
+	CardFieldAssignedBy:   json.RawMessage("null"),
+	CardFieldReceivedAt:   json.RawMessage("null"),
+	CardFieldStartAt:      json.RawMessage("null"),
+	CardFieldDueAt:        json.RawMessage("null"),
+	CardFieldEndAt:        json.RawMessage("null"),
+	CardFieldSpentTime:    json.RawMessage("null"),
+	CardFieldCustomFields: json.RawMessage("[]"),
+	CardFieldMembers:      json.RawMessage("[]"),
+	CardFieldAssignees:    json.RawMessage("[]"),
+}
+
+// MarshalJSON implements json.Marshaler.
+// It adds the gantt links and the fields of ClearFields as null values.
+func (o EditCardOptions) MarshalJSON() ([]byte, error) {
+	// Prevent recursion by using a type without the MarshalJSON method.
+	type editCardOptions EditCardOptions
+
+	data, err := json.Marshal(editCardOptions(o))
+	if err != nil || (len(o.ClearFields) == 0 && o.GanttLinks == nil) {
+		return data, err
+	}
+
+	var fields map[string]json.RawMessage
+	err = json.Unmarshal(data, &fields)
+	if err != nil {
+		return nil, err
+	}
+
+	if o.GanttLinks != nil {
+		var (
+			targetIDs = make([]string, len(o.GanttLinks))
+			linkTypes = make([]int, len(o.GanttLinks))
+			linkIDs   = make([]string, len(o.GanttLinks))
+		)
+		for i, l := range o.GanttLinks {
+			targetIDs[i] = l.TargetID
+			linkTypes[i] = l.LinkType
+			linkIDs[i] = l.LinkID
+		}
+
+		fields["targetId_gantt"], _ = json.Marshal(targetIDs)
+		fields["linkType_gantt"], _ = json.Marshal(linkTypes)
+		fields["linkId_gantt"], _ = json.Marshal(linkIDs)
+	}
+
+	for _, f := range o.ClearFields {
+		value, ok := clearableCardFields[f]
+		if !ok {
+			return nil, fmt.Errorf("card field '%s' can not be cleared", f)
+		} else if _, ok := fields[f]; ok {
+			return nil, fmt.Errorf("card field '%s' is set and cleared at the same time", f)
+		}
+		fields[f] = value
+	}
+
+	return json.Marshal(fields)
+}
+
+// CardDates are the dates of a card. Nil dates are left unchanged.
+type CardDates struct {
+	ReceivedAt *time.Time
+	StartAt    *time.Time
+	DueAt      *time.Time
+	EndAt      *time.Time
+}
+
+func (d CardDates) validate() error {
+	if d.StartAt != nil && d.DueAt != nil && d.DueAt.Before(*d.StartAt) {
+		return fmt.Errorf("invalid card dates: due date %s is before start date %s", d.DueAt, d.StartAt)
+	}
+	if d.DueAt != nil && d.EndAt != nil && d.EndAt.Before(*d.DueAt) {
+		return fmt.Errorf("invalid card dates: end date %s is before due date %s", d.EndAt, d.DueAt)
+	}
+	return nil
 }
 
 type EditCardResponse struct {
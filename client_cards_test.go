@@ -0,0 +1,329 @@
+/**
+ * Copyright (c) 2023 Sebastian Borchers
+ *
+ * This software is released under the MIT License.
+ * https://opensource.org/licenses/MIT
+ */
+
+package wego
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestEditCardOptionsMarshalOmitsUnsetFields(t *testing.T) {
+	due := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		opts EditCardOptions
+		want string
+	}{
+		{
+			name: "empty",
+			want: `{}`,
+		},
+		{
+			name: "zero values are sent",
+			opts: EditCardOptions{Title: Ptr(""), Sort: Ptr(0.0), IsOverTime: Ptr(false)},
+			want: `{"title":"","sort":0,"isOverTime":false}`,
+		},
+		{
+			name: "single field",
+			opts: EditCardOptions{Description: Ptr("text")},
+			want: `{"description":"text"}`,
+		},
+		{
+			name: "move",
+			opts: EditCardOptions{ListID: Ptr("l"), SwimlaneID: Ptr("s")},
+			want: `{"listId":"l","swimlaneId":"s"}`,
+		},
+		{
+			name: "dates and arrays",
+			opts: EditCardOptions{DueAt: &due, LabelIDs: []string{"a"}, Members: []string{"m"}},
+			want: `{"dueAt":"2023-01-02T03:04:05Z","labelIds":["a"],"members":["m"]}`,
+		},
+		{
+			name: "gantt links",
+			opts: EditCardOptions{GanttLinks: []GanttLink{{TargetID: "t", LinkType: 1, LinkID: "l"}}},
+			want: `{"targetId_gantt":["t"],"linkType_gantt":[1],"linkId_gantt":["l"]}`,
+		},
+		{
+			name: "no gantt links",
+			opts: EditCardOptions{GanttLinks: []GanttLink{}},
+			want: `{"targetId_gantt":[],"linkType_gantt":[],"linkId_gantt":[]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertJSON(t, tt.opts, tt.want)
+		})
+	}
+}
+
+func TestEditCardOptionsMarshalClearFields(t *testing.T) {
+	tests := []struct {
+		field string
+		want  string
+	}{
+		{CardFieldParentID, `{"parentId":null}`},
+		{CardFieldDescription, `{"description":null}`},
+		{CardFieldColor, `{"color":null}`},
+		{CardFieldVote, `{"vote":null}`},
+		{CardFieldPoker, `{"poker":null}`},
+		{CardFieldLabelIDs, `{"labelIds":[]}`},
+		{CardFieldRequestedBy, `{"requestedBy":null}`},
+		{CardFieldAssignedBy, `{"assignedBy":null}`},
+		{CardFieldReceivedAt, `{"receivedAt":null}`},
+		{CardFieldStartAt, `{"startAt":null}`},
+		{CardFieldDueAt, `{"dueAt":null}`},
+		{CardFieldEndAt, `{"endAt":null}`},
+		{CardFieldSpentTime, `{"spentTime":null}`},
+		{CardFieldCustomFields, `{"customFields":[]}`},
+		{CardFieldMembers, `{"members":[]}`},
+		{CardFieldAssignees, `{"assignees":[]}`},
+	}
+
+	// Every clearable field must be covered.
+	if len(tests) != len(clearableCardFields) {
+		t.Fatalf("%d clearable card fields, but %d are tested", len(clearableCardFields), len(tests))
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.field, func(t *testing.T) {
+			assertJSON(t, EditCardOptions{ClearFields: []string{tt.field}}, tt.want)
+		})
+	}
+
+	t.Run("combined with set fields", func(t *testing.T) {
+		opts := EditCardOptions{Title: Ptr("title"), ClearFields: []string{CardFieldDueAt, CardFieldMembers}}
+		assertJSON(t, opts, `{"title":"title","dueAt":null,"members":[]}`)
+	})
+
+	t.Run("unknown field", func(t *testing.T) {
+		_, err := EditCardOptions{ClearFields: []string{"title"}}.MarshalJSON()
+		if err == nil {
+			t.Fatal("expected an error for a field that can not be cleared")
+		}
+	})
+
+	t.Run("set and cleared", func(t *testing.T) {
+		_, err := EditCardOptions{Color: Ptr("red"), ClearFields: []string{CardFieldColor}}.MarshalJSON()
+		if err == nil {
+			t.Fatal("expected an error for a field that is set and cleared")
+		}
+	})
+}
+
+func TestGetCardsByCustomFieldFull(t *testing.T) {
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/boards/b/cardsByCustomField/f/v":
+			writeJSON(w, []GetCardByCustomField{
+				{ID: "c1", ListID: "l1"},
+				{ID: "missing", ListID: "l1"},
+				{ID: "c2", ListID: "l2"},
+			})
+		case "/api/boards/b/lists/l1/cards/c1":
+			writeJSON(w, GetCard{ID: "c1", ListID: "l1"})
+		case "/api/boards/b/lists/l2/cards/c2":
+			writeJSON(w, GetCard{ID: "c2", ListID: "l2"})
+		default:
+			writeErrorStatus(w, http.StatusNotFound)
+		}
+	})
+	c := newTestClient(t, srv, Options{MaxConcurrency: 2})
+
+	cards, err := c.GetCardsByCustomFieldFull(context.Background(), "b", "f", "v")
+
+	var batchErr *BatchError
+	if !errors.As(err, &batchErr) {
+		t.Fatalf("expected a *BatchError, got: %v", err)
+	} else if len(batchErr.Errors) != 1 || !errors.Is(batchErr.Errors[1], ErrNotFound) {
+		t.Fatalf("expected the second match to fail with ErrNotFound, got: %v", batchErr.Errors)
+	}
+
+	if len(cards) != 2 || cards[0].ID != "c1" || cards[1].ID != "c2" {
+		t.Fatalf("expected the cards c1 and c2 in order, got %+v", cards)
+	}
+}
+
+func TestValidateCardCustomFields(t *testing.T) {
+	type nested struct {
+		Ch chan int
+	}
+
+	tests := []struct {
+		name    string
+		value   any
+		wantErr bool
+	}{
+		{name: "nil"},
+		{name: "string", value: "text"},
+		{name: "number", value: 1.5},
+		{name: "slice", value: []string{"a", "b"}},
+		{name: "map", value: map[string]any{"a": 1}},
+		{name: "chan", value: make(chan int), wantErr: true},
+		{name: "func", value: func() {}, wantErr: true},
+		{name: "complex", value: complex(1, 2), wantErr: true},
+		{name: "chan in map", value: map[string]any{"a": make(chan int)}, wantErr: true},
+		{name: "func in slice", value: []any{"a", func() {}}, wantErr: true},
+		{name: "chan in struct", value: nested{Ch: make(chan int)}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateCardCustomFields([]CardCustomField{{ID: "ok", Value: "ok"}, {ID: "field", Value: tt.value}})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error %v, got: %v", tt.wantErr, err)
+			} else if err != nil && (!strings.Contains(err.Error(), "customFields[1]") || !strings.Contains(err.Error(), "'field'")) {
+				t.Fatalf("expected the error to name the field, got: %v", err)
+			}
+		})
+	}
+}
+
+func TestMoveCardToEdgeUsesListSortValues(t *testing.T) {
+	tests := []struct {
+		name         string
+		sendSort     bool
+		top          bool
+		wantSort     float64
+		wantGetCards int64
+	}{
+		{name: "top", sendSort: true, top: true, wantSort: -1},
+		{name: "bottom", sendSort: true, wantSort: 6},
+		{name: "top without sort values", top: true, wantSort: -1, wantGetCards: 3},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			cards := []GetCard{{ID: "a", Sort: 0}, {ID: "b", Sort: 5}, {ID: "c", Sort: 2}}
+
+			var (
+				getCards atomic.Int64
+				sent     atomic.Value
+			)
+			srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.URL.Path == "/api/boards/b/lists/l/cards":
+					all := make([]map[string]any, len(cards))
+					for i, card := range cards {
+						all[i] = map[string]any{"_id": card.ID}
+						if tt.sendSort {
+							all[i]["sort"] = card.Sort
+						}
+					}
+					writeJSON(w, all)
+
+				case r.Method == http.MethodGet:
+					getCards.Add(1)
+					id := strings.TrimPrefix(r.URL.Path, "/api/boards/b/lists/l/cards/")
+					for _, card := range cards {
+						if card.ID == id {
+							writeJSON(w, card)
+							return
+						}
+					}
+					writeErrorStatus(w, http.StatusNotFound)
+
+				case r.Method == http.MethodPut && r.URL.Path == "/api/boards/b/lists/l/cards/c":
+					var opts struct {
+						Sort float64 `json:"sort"`
+					}
+					json.NewDecoder(r.Body).Decode(&opts)
+					sent.Store(opts.Sort)
+					writeJSON(w, EditCardResponse{ID: "c"})
+
+				default:
+					writeErrorStatus(w, http.StatusNotFound)
+				}
+			})
+			c := newTestClient(t, srv, Options{})
+
+			var err error
+			if tt.top {
+				err = c.MoveCardToTop(context.Background(), "b", "l", "c")
+			} else {
+				err = c.MoveCardToBottom(context.Background(), "b", "l", "c")
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got, _ := sent.Load().(float64); got != tt.wantSort {
+				t.Fatalf("expected sort value %v, got %v", tt.wantSort, got)
+			} else if n := getCards.Load(); n != tt.wantGetCards {
+				t.Fatalf("expected %d get_card requests, got %d", tt.wantGetCards, n)
+			}
+		})
+	}
+}
+
+func TestNewCardFromTemplateFetchesTemplateOnly(t *testing.T) {
+	var (
+		mx    sync.Mutex
+		paths []string
+	)
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		mx.Lock()
+		paths = append(paths, r.Method+" "+r.URL.Path)
+		mx.Unlock()
+
+		switch r.Method + " " + r.URL.Path {
+		case "GET /api/user":
+			writeJSON(w, map[string]any{"profile": map[string]string{"templatesBoardId": "tb"}})
+		case "GET /api/boards/tb/cards/t":
+			writeJSON(w, GetCard{ID: "t", Title: "template", Type: CardTypeTemplate})
+		case "GET /api/boards/tb/cards/c":
+			writeJSON(w, GetCard{ID: "c", Title: "card", Type: CardTypeCard})
+		case "POST /api/boards/b/lists/l/cards":
+			writeJSON(w, NewCardResponse{ID: "new"})
+		case "GET /api/boards/tb/cards/t/checklists":
+			writeJSON(w, []GetAllChecklist{})
+		case "PUT /api/boards/b/lists/l/cards/new":
+			writeJSON(w, EditCardResponse{ID: "new"})
+		default:
+			writeErrorStatus(w, http.StatusNotFound)
+		}
+	})
+	c := newTestClient(t, srv, Options{})
+	ctx := context.Background()
+
+	r, err := c.NewCardFromTemplate(ctx, "b", "l", "t", EditCardOptions{SwimlaneID: Ptr("s")})
+	if err != nil {
+		t.Fatal(err)
+	} else if r.ID != "new" {
+		t.Fatalf("expected the card 'new', got '%s'", r.ID)
+	}
+
+	mx.Lock()
+	want := []string{
+		"GET /api/user",
+		"GET /api/boards/tb/cards/t",
+		"POST /api/boards/b/lists/l/cards",
+		"GET /api/boards/tb/cards/t/checklists",
+		"PUT /api/boards/b/lists/l/cards/new",
+	}
+	got := append([]string(nil), paths...)
+	mx.Unlock()
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected requests %q, got %q", want, got)
+	}
+
+	_, err = c.NewCardFromTemplate(ctx, "b", "l", "c", EditCardOptions{})
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for a card that is no template, got: %v", err)
+	}
+}
@@ -7,7 +7,59 @@
 
 package wego
 
-import "context"
+import (
+	"context"
+	"fmt"
+)
+
+// NewChecklistItem performs a new_checklist_item request against the Wekan server.
+// See https://wekan.github.io/api/v6.97/#new_checklist_item
+func (c *Client) NewChecklistItem(ctx context.Context, boardID, cardID, checklistID, title string) (r NewChecklistItemResponse, err error) {
+	endpoint := c.endpoint("boards", boardID, "cards", cardID, "checklists", checklistID, "items")
+
+	req, err := c.newAuthenticatedPOSTRequest(ctx, endpoint, newChecklistItemRequest{Title: title})
+	if err != nil {
+		return
+	}
+
+	err = c.doSimpleRequest(req, &r)
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+// AddChecklistItems adds an item for each title to an existing checklist.
+// The items are created one after another to preserve their order and the ids
+// of the new items are returned in the same order.
+// It performs a new_checklist_item request per title.
+//
+// If some items could not be created, their ids are empty and a *BatchError is returned,
+// keyed by the index of the title in titles.
+func (c *Client) AddChecklistItems(ctx context.Context, boardID, cardID, checklistID string, titles []string) (itemIDs []string, err error) {
+	itemIDs = make([]string, len(titles))
+	errs := make(map[int]error)
+
+	for i, title := range titles {
+		if ctx.Err() != nil {
+			errs[i] = ctx.Err()
+			continue
+		}
+
+		r, err := c.NewChecklistItem(ctx, boardID, cardID, checklistID, title)
+		if err != nil {
+			errs[i] = fmt.Errorf("item '%s': %w", title, err)
+			continue
+		}
+		itemIDs[i] = r.ID
+	}
+
+	if len(errs) > 0 {
+		err = &BatchError{Errors: errs}
+	}
+	return
+}
 
 // GetChecklistItem performs a get_checklist_item request against the Wekan server.
 // See https://wekan.github.io/api/v5.13/#get_checklist_item
@@ -29,7 +81,13 @@ func (c *Client) GetChecklistItem(ctx context.Context, boardID, cardID, checklis
 
 // EditChecklistItem performs a edit_checklist_item request against the Wekan server.
 // See https://wekan.github.io/api/v5.13/#edit_checklist_item
+//
+// Only the set fields of data are changed, e.g. renaming an item keeps its finished state.
 func (c *Client) EditChecklistItem(ctx context.Context, boardID, cardID, checklistID, itemID string, data EditChecklistItemRequest) (err error) {
+	if data.Title == nil && data.IsFinished == nil {
+		return fmt.Errorf("edit checklist item '%s': no field set", itemID)
+	}
+
 	endpoint := c.endpoint("boards", boardID, "cards", cardID, "checklists", checklistID, "items", itemID)
 
 	req, err := c.newAuthenticatedPUTRequest(ctx, endpoint, data)
@@ -67,7 +125,17 @@ type GetChecklistItem struct {
 	ModifiedAt  string `json:"modifiedAt"`
 }
 
+type newChecklistItemRequest struct {
+	Title string `json:"title"`
+}
+
+type NewChecklistItemResponse struct {
+	ID string `json:"_id"`
+}
+
+// EditChecklistItemRequest is the body of an edit_checklist_item request.
+// Nil fields are omitted and remain unchanged on the server.
 type EditChecklistItemRequest struct {
-	Title      string `json:"title"`
-	IsFinished bool   `json:"isFinished"`
+	Title      *string `json:"title,omitempty"`
+	IsFinished *bool   `json:"isFinished,omitempty"`
 }
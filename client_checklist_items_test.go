@@ -0,0 +1,86 @@
+/**
+ * Copyright (c) 2023 Sebastian Borchers
+ *
+ * This software is released under the MIT License.
+ * https://opensource.org/licenses/MIT
+ */
+
+package wego
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"sync"
+	"testing"
+)
+
+func TestEditChecklistItemPartialUpdate(t *testing.T) {
+	const itemPath = "/api/boards/b/cards/c/checklists/cl/items/i"
+
+	var (
+		mx     sync.Mutex
+		item   = GetChecklistItem{Title: "item"}
+		bodies []string
+	)
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != itemPath {
+			http.NotFound(w, r)
+			return
+		}
+
+		mx.Lock()
+		defer mx.Unlock()
+
+		switch r.Method {
+		case http.MethodGet:
+			writeJSON(w, item)
+		case http.MethodPut:
+			// Apply the sent fields only, like Wekan does.
+			body, _ := io.ReadAll(r.Body)
+			bodies = append(bodies, string(body))
+			json.Unmarshal(body, &item)
+			writeJSON(w, map[string]string{"_id": "i"})
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	})
+	c := newTestClient(t, srv, Options{})
+	ctx := context.Background()
+
+	err := c.EditChecklistItem(ctx, "b", "c", "cl", "i", EditChecklistItemRequest{IsFinished: Ptr(true)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = c.EditChecklistItem(ctx, "b", "c", "cl", "i", EditChecklistItemRequest{Title: Ptr("renamed")})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := c.GetChecklistItem(ctx, "b", "c", "cl", "i")
+	if err != nil {
+		t.Fatal(err)
+	} else if got.Title != "renamed" || !got.IsFinished {
+		t.Fatalf("expected the renamed item to stay finished, got %+v", got)
+	}
+
+	if len(bodies) != 2 || bodies[1] != `{"title":"renamed"}` {
+		t.Fatalf("expected the rename to send the title only, got bodies %q", bodies)
+	}
+
+	// An empty request is rejected without contacting the server.
+	err = c.EditChecklistItem(ctx, "b", "c", "cl", "i", EditChecklistItemRequest{})
+	if err == nil {
+		t.Fatal("expected an error for an empty request")
+	} else if len(bodies) != 2 {
+		t.Fatal("empty request has been sent")
+	}
+}
+
+func TestEditChecklistItemRequestMarshalOmitsUnsetFields(t *testing.T) {
+	assertJSON(t, EditChecklistItemRequest{}, `{}`)
+	assertJSON(t, EditChecklistItemRequest{IsFinished: Ptr(false)}, `{"isFinished":false}`)
+	assertJSON(t, EditChecklistItemRequest{Title: Ptr("")}, `{"title":""}`)
+	assertJSON(t, EditChecklistItemRequest{Title: Ptr("t"), IsFinished: Ptr(true)}, `{"title":"t","isFinished":true}`)
+}
@@ -7,7 +7,10 @@
 
 package wego
 
-import "context"
+import (
+	"context"
+	"fmt"
+)
 
 // GetAllChecklists performs a get_all_checklists request against the Wekan server.
 // See https://wekan.github.io/api/v5.13/#get_all_checklists
@@ -45,6 +48,27 @@ func (c *Client) NewChecklist(ctx context.Context, boardID, cardID string, data
 	return
 }
 
+// NewChecklistWithItems is like NewChecklist, but returns the created checklist including
+// the ids of its items, which are needed to edit or delete them later.
+//
+// Note: The new_checklist response only holds the id of the checklist, therefore the
+// checklist is fetched with GetChecklist afterwards.
+func (c *Client) NewChecklistWithItems(ctx context.Context, boardID, cardID string, data NewChecklistRequest) (checklist GetChecklist, err error) {
+	r, err := c.NewChecklist(ctx, boardID, cardID, data)
+	if err != nil {
+		return
+	}
+
+	checklist, err = c.GetChecklist(ctx, boardID, cardID, r.ID)
+	if err != nil {
+		err = fmt.Errorf("failed to get created checklist '%s': %w", r.ID, err)
+		return
+	}
+
+	checklist.ID = r.ID
+	return
+}
+
 // GetChecklist performs a get_checklist request against the Wekan server.
 // See https://wekan.github.io/api/v5.13/#get_checklist
 func (c *Client) GetChecklist(ctx context.Context, boardID, cardID, checklistID string) (checklist GetChecklist, err error) {
@@ -95,6 +119,7 @@ type NewChecklistResponse struct {
 }
 
 type GetChecklist struct {
+	ID         string          `json:"_id"`
 	CardId     string          `json:"cardId"`
 	Title      string          `json:"title"`
 	FinishedAt string          `json:"finishedAt"`
@@ -7,7 +7,16 @@
 
 package wego
 
-import "context"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
 
 // GetAllCustomFields performs a get_all_custom_fields request against the Wekan server.
 // See https://wekan.github.io/api/v5.13/#get_all_custom_fields
@@ -30,6 +39,11 @@ func (c *Client) GetAllCustomFields(ctx context.Context, boardID string) (fields
 // NewCustomField performs a new_custom_field request against the Wekan server.
 // See https://wekan.github.io/api/v5.13/#new_custom_field
 func (c *Client) NewCustomField(ctx context.Context, boardID string, data NewCustomFieldRequest) (r NewCustomFieldResponse, err error) {
+	err = data.Type.validate()
+	if err != nil {
+		return
+	}
+
 	endpoint := c.endpoint("boards", boardID, "custom-fields")
 
 	req, err := c.newAuthenticatedPOSTRequest(ctx, endpoint, data)
@@ -63,9 +77,40 @@ func (c *Client) GetCustomField(ctx context.Context, boardID string) (resp []Get
 	return
 }
 
+// GetCustomFieldDetails performs a get_custom_field request against the Wekan server
+// and returns the full definition of the custom field, including its settings.
+// See https://wekan.github.io/api/v5.13/#get_custom_field
+//
+// Returns ErrNotFound, if the custom field could not be found.
+func (c *Client) GetCustomFieldDetails(ctx context.Context, boardID, customFieldID string) (field CustomFieldDetail, err error) {
+	endpoint := c.endpoint("boards", boardID, "custom-fields", customFieldID)
+
+	req, err := c.newAuthenticatedGETRequest(ctx, endpoint)
+	if err != nil {
+		return
+	}
+
+	err = c.doSimpleRequest(req, &field)
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			err = ErrNotFound
+		}
+		return
+	}
+
+	return
+}
+
 // EditCustomField performs a edit_custom_field request against the Wekan server.
 // See https://wekan.github.io/api/v5.13/#edit_custom_field
 func (c *Client) EditCustomField(ctx context.Context, boardID string, data EditCustomFieldRequest) (r EditCustomFieldResponse, err error) {
+	if data.Type != nil {
+		err = data.Type.validate()
+		if err != nil {
+			return
+		}
+	}
+
 	endpoint := c.endpoint("boards", boardID, "custom-fields")
 
 	req, err := c.newAuthenticatedPUTRequest(ctx, endpoint, data)
@@ -109,6 +154,58 @@ func (c *Client) AddCustomFieldDropdownItems(ctx context.Context, boardID, custo
 	return c.doSimpleRequest(req, nil)
 }
 
+// AddCustomFieldDropdownItemsWithIDs is like AddCustomFieldDropdownItems, but returns
+// the created items along with their ids, in the order of the given names.
+// The ids are required to set the value of a dropdown custom field on a card.
+//
+// Note: The server does not respond with the created items, therefore the custom field
+// is fetched before and after adding them to determine their ids.
+func (c *Client) AddCustomFieldDropdownItemsWithIDs(ctx context.Context, boardID, customFieldID string, items []string) (created []DropdownItem, err error) {
+	before, err := c.GetCustomFieldDetails(ctx, boardID, customFieldID)
+	if err != nil {
+		return
+	}
+
+	err = c.AddCustomFieldDropdownItems(ctx, boardID, customFieldID, items)
+	if err != nil {
+		return
+	}
+
+	after, err := c.GetCustomFieldDetails(ctx, boardID, customFieldID)
+	if err != nil {
+		return
+	}
+
+	existing := make(map[string]struct{}, len(before.Settings.DropdownItems))
+	for _, item := range before.Settings.DropdownItems {
+		existing[item.ID] = struct{}{}
+	}
+
+	// Match the new items to the given names. Names may repeat, therefore every new
+	// item is matched only once.
+	var added []DropdownItem
+	for _, item := range after.Settings.DropdownItems {
+		if _, ok := existing[item.ID]; !ok {
+			added = append(added, item)
+		}
+	}
+
+	created = make([]DropdownItem, 0, len(items))
+	for _, name := range items {
+		i := 0
+		for ; i < len(added) && added[i].Name != name; i++ {
+		}
+		if i == len(added) {
+			return created, fmt.Errorf("dropdown item '%s' not found after adding it", name)
+		}
+
+		created = append(created, added[i])
+		added = append(added[:i], added[i+1:]...)
+	}
+
+	return
+}
+
 // EditCustomFieldDropdownItems performs a edit_custom_field_dropdown_items request against the Wekan server.
 // See https://wekan.github.io/api/v5.13/#edit_custom_field_dropdown_items
 func (c *Client) EditCustomFieldDropdownItems(ctx context.Context, boardID, customFieldID, dropdownItem, name string) (err error) {
@@ -137,24 +234,185 @@ func (c *Client) DeleteCustomFieldDropdownItem(ctx context.Context, boardID, cus
 	return c.doSimpleRequest(req, nil)
 }
 
+// SetCardCustomFieldValue sets the value of a custom field on a card.
+// It performs a get_card and an edit_card request.
+//
+// Note: Since edit_card replaces all custom fields of a card, this method performs a
+// read-modify-write: The card's current custom fields are read, the target field is
+// updated or inserted and the merged fields are sent. Concurrent modifications of the
+// card's custom fields between the read and the write are lost.
+//
+// Returns ErrNotFound, if the card could not be found.
+func (c *Client) SetCardCustomFieldValue(ctx context.Context, boardID, listID, cardID, customFieldID string, value any) (err error) {
+	card, err := c.GetCard(ctx, boardID, listID, cardID)
+	if err != nil {
+		return
+	}
+
+	fields := make([]CardCustomField, 0, len(card.CustomFields)+1)
+	found := false
+	for _, f := range card.CustomFields {
+		if f.ID == customFieldID {
+			f.Value = value
+			found = true
+		}
+		fields = append(fields, f)
+	}
+	if !found {
+		fields = append(fields, CardCustomField{ID: customFieldID, Value: value})
+	}
+
+	_, err = c.EditCard(ctx, boardID, listID, cardID, EditCardOptions{CustomFields: fields})
+	return
+}
+
+// SetCardCustomFieldByName sets the value of the custom field with the given name on a card.
+// The name is resolved to the id of the field with the board's custom field definitions,
+// which are cached by the client. The value is set with SetCardCustomFieldValue.
+// Resolving the name costs a get_all_custom_fields request, unless the cached definitions
+// already hold it.
+//
+// Returns ErrNotFound, if the board has no custom field with the given name.
+func (c *Client) SetCardCustomFieldByName(ctx context.Context, boardID, listID, cardID, fieldName string, value any) (err error) {
+	fieldID, err := c.customFieldIDByName(ctx, boardID, fieldName)
+	if err != nil {
+		return
+	}
+
+	return c.SetCardCustomFieldValue(ctx, boardID, listID, cardID, fieldID, value)
+}
+
+// GetCardCustomFieldValue returns the value of a custom field of a card and
+// whether the card has a value for the field at all.
+// It performs a single get_card request.
+//
+// Returns ErrNotFound, if the card could not be found.
+func (c *Client) GetCardCustomFieldValue(ctx context.Context, boardID, listID, cardID, customFieldID string) (value any, ok bool, err error) {
+	card, err := c.GetCard(ctx, boardID, listID, cardID)
+	if err != nil {
+		return
+	}
+
+	for _, f := range card.CustomFields {
+		if f.ID == customFieldID {
+			return f.Value, f.Value != nil, nil
+		}
+	}
+
+	return
+}
+
+//################//
+//### Internal ###//
+//################//
+
+// How long the custom field definitions of a board are cached.
+const customFieldCacheTTL = 5 * time.Minute
+
+// customFieldCache caches the custom field definitions of boards.
+type customFieldCache struct {
+	mx      sync.Mutex
+	entries map[string]customFieldCacheEntry
+}
+
+type customFieldCacheEntry struct {
+	fields  []GetAllCustomField
+	expires time.Time
+}
+
+// customFieldIDByName resolves the name of a custom field of the board to its id.
+// Cached definitions are refreshed once, if they do not contain the name.
+func (c *Client) customFieldIDByName(ctx context.Context, boardID, name string) (id string, err error) {
+	find := func(fields []GetAllCustomField) (string, bool) {
+		for _, f := range fields {
+			if f.Name == name {
+				return f.ID, true
+			}
+		}
+		return "", false
+	}
+
+	cf := &c.customFields
+	cf.mx.Lock()
+	e, ok := cf.entries[boardID]
+	cf.mx.Unlock()
+	if ok && time.Now().Before(e.expires) {
+		if id, ok = find(e.fields); ok {
+			return
+		}
+	}
+
+	fields, err := c.GetAllCustomFields(ctx, boardID)
+	if err != nil {
+		return
+	}
+
+	cf.mx.Lock()
+	if cf.entries == nil {
+		cf.entries = make(map[string]customFieldCacheEntry)
+	}
+	cf.entries[boardID] = customFieldCacheEntry{fields: fields, expires: time.Now().Add(customFieldCacheTTL)}
+	cf.mx.Unlock()
+
+	if id, ok = find(fields); !ok {
+		err = fmt.Errorf("custom field '%s': %w", name, ErrNotFound)
+	}
+	return
+}
+
 //#############//
 //### Types ###//
 //#############//
 
 type GetAllCustomField struct {
-	ID   string `json:"_id"`
-	Name string `json:"name"`
-	Type string `json:"type"`
+	ID   string          `json:"_id"`
+	Name string          `json:"name"`
+	Type CustomFieldType `json:"type"`
 }
 
 type NewCustomFieldRequest struct {
-	Name                string `json:"name"`
-	Type                string `json:"type"`
-	Settings            string `json:"settings"`
-	ShowOnCard          bool   `json:"showOnCard"`
-	AutomaticallyOnCard bool   `json:"automaticallyOnCard"`
-	ShowLabelOnMiniCard bool   `json:"showLabelOnMiniCard"`
-	AuthorId            string `json:"authorId"`
+	Name                string          `json:"name"`
+	Type                CustomFieldType `json:"type"`
+	Settings            string          `json:"settings"`
+	ShowOnCard          bool            `json:"showOnCard"`
+	AutomaticallyOnCard bool            `json:"automaticallyOnCard"`
+	ShowLabelOnMiniCard bool            `json:"showLabelOnMiniCard"`
+	AuthorId            string          `json:"authorId"`
+}
+
+// CustomFieldType is the type of a custom field.
+type CustomFieldType string
+
+const (
+	CustomFieldTypeText           CustomFieldType = "text"
+	CustomFieldTypeNumber         CustomFieldType = "number"
+	CustomFieldTypeDate           CustomFieldType = "date"
+	CustomFieldTypeDropdown       CustomFieldType = "dropdown"
+	CustomFieldTypeCheckbox       CustomFieldType = "checkbox"
+	CustomFieldTypeCurrency       CustomFieldType = "currency"
+	CustomFieldTypeStringTemplate CustomFieldType = "stringtemplate"
+)
+
+// Known reports, whether t is one of the custom field types defined in this package.
+func (t CustomFieldType) Known() bool {
+	switch t {
+	case CustomFieldTypeText, CustomFieldTypeNumber, CustomFieldTypeDate, CustomFieldTypeDropdown,
+		CustomFieldTypeCheckbox, CustomFieldTypeCurrency, CustomFieldTypeStringTemplate:
+		return true
+	default:
+		return false
+	}
+}
+
+// validate ensures that t is set. Unknown types are sent anyway for compatibility with
+// newer Wekan versions, but a warning is logged, since Wekan silently ignores invalid ones.
+func (t CustomFieldType) validate() error {
+	if t == "" {
+		return fmt.Errorf("custom field type must be set")
+	} else if !t.Known() {
+		log.Warn().Str("type", string(t)).Msg("unknown custom field type")
+	}
+	return nil
 }
 
 type NewCustomFieldResponse struct {
@@ -166,14 +424,44 @@ type GetCustomField struct {
 	BoardIDs string `json:"boardIds"`
 }
 
+type CustomFieldDetail struct {
+	ID                  string              `json:"_id"`
+	BoardIDs            []string            `json:"boardIds"`
+	Name                string              `json:"name"`
+	Type                CustomFieldType     `json:"type"`
+	Settings            CustomFieldSettings `json:"settings"`
+	ShowOnCard          bool                `json:"showOnCard"`
+	AutomaticallyOnCard bool                `json:"automaticallyOnCard"`
+	AlwaysOnCard        bool                `json:"alwaysOnCard"`
+	ShowLabelOnMiniCard bool                `json:"showLabelOnMiniCard"`
+}
+
+// CustomFieldSettings are the type specific settings of a custom field.
+type CustomFieldSettings struct {
+	// Set for fields of type currency.
+	CurrencyCode string `json:"currencyCode,omitempty"`
+	// Set for fields of type dropdown.
+	DropdownItems []DropdownItem `json:"dropdownItems,omitempty"`
+	// Set for fields of type stringtemplate.
+	StringTemplateFormat    string `json:"stringtemplateFormat,omitempty"`
+	StringTemplateSeparator string `json:"stringtemplateSeparator,omitempty"`
+}
+
+type DropdownItem struct {
+	ID   string `json:"_id"`
+	Name string `json:"name"`
+}
+
+// EditCustomFieldRequest is the body of an edit_custom_field request.
+// Nil fields are omitted and remain unchanged on the server.
 type EditCustomFieldRequest struct {
-	Name                string `json:"name"`
-	Type                string `json:"type"`
-	Settings            string `json:"settings"`
-	ShowOnCard          bool   `json:"showOnCard"`
-	AutomaticallyOnCard bool   `json:"automaticallyOnCard"`
-	AlwaysOnCard        bool   `json:"alwaysOnCard"`
-	ShowLabelOnMiniCard bool   `json:"showLabelOnMiniCard"`
+	Name                *string          `json:"name,omitempty"`
+	Type                *CustomFieldType `json:"type,omitempty"`
+	Settings            *string          `json:"settings,omitempty"`
+	ShowOnCard          *bool            `json:"showOnCard,omitempty"`
+	AutomaticallyOnCard *bool            `json:"automaticallyOnCard,omitempty"`
+	AlwaysOnCard        *bool            `json:"alwaysOnCard,omitempty"`
+	ShowLabelOnMiniCard *bool            `json:"showLabelOnMiniCard,omitempty"`
 }
 
 type EditCustomFieldResponse struct {
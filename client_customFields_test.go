@@ -0,0 +1,22 @@
+/**
+ * Copyright (c) 2023 Sebastian Borchers
+ *
+ * This software is released under the MIT License.
+ * https://opensource.org/licenses/MIT
+ */
+
+package wego
+
+import "testing"
+
+func TestEditCustomFieldRequestMarshalOmitsUnsetFields(t *testing.T) {
+	assertJSON(t, EditCustomFieldRequest{}, `{}`)
+	assertJSON(t, EditCustomFieldRequest{Name: Ptr("name")}, `{"name":"name"}`)
+	assertJSON(t, EditCustomFieldRequest{Type: Ptr(CustomFieldTypeNumber)}, `{"type":"number"}`)
+	assertJSON(t, EditCustomFieldRequest{ShowOnCard: Ptr(false), AlwaysOnCard: Ptr(true)}, `{"showOnCard":false,"alwaysOnCard":true}`)
+	assertJSON(t, EditCustomFieldRequest{
+		Settings:            Ptr(""),
+		AutomaticallyOnCard: Ptr(false),
+		ShowLabelOnMiniCard: Ptr(false),
+	}, `{"settings":"","automaticallyOnCard":false,"showLabelOnMiniCard":false}`)
+}
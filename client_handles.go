@@ -0,0 +1,276 @@
+/**
+ * Copyright (c) 2023 Sebastian Borchers
+ *
+ * This software is released under the MIT License.
+ * https://opensource.org/licenses/MIT
+ */
+
+package wego
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// BoardClient is a handle to a single board.
+// It carries the board's id implicitly and wraps the flat methods of Client.
+type BoardClient struct {
+	c  *Client
+	id string
+}
+
+// Board returns a handle to the board with the given id.
+// The board is not fetched, the handle is valid even if the board does not exist.
+func (c *Client) Board(boardID string) *BoardClient {
+	return &BoardClient{c: c, id: boardID}
+}
+
+// ID returns the id of the board.
+func (b *BoardClient) ID() string {
+	return b.id
+}
+
+// Get see Client.GetBoard.
+func (b *BoardClient) Get(ctx context.Context) (GetBoard, error) {
+	return b.c.GetBoard(ctx, b.id)
+}
+
+// Exists see Client.BoardExists.
+func (b *BoardClient) Exists(ctx context.Context) (bool, error) {
+	return b.c.BoardExists(ctx, b.id)
+}
+
+// Delete see Client.DeleteBoard.
+func (b *BoardClient) Delete(ctx context.Context) error {
+	return b.c.DeleteBoard(ctx, b.id)
+}
+
+// Members returns the members of the board, see Client.GetBoard.
+func (b *BoardClient) Members(ctx context.Context) ([]BoardMember, error) {
+	board, err := b.c.GetBoard(ctx, b.id)
+	if err != nil {
+		return nil, err
+	}
+	return board.Members, nil
+}
+
+// Labels see Client.GetBoardLabels.
+func (b *BoardClient) Labels(ctx context.Context) ([]BoardLabel, error) {
+	return b.c.GetBoardLabels(ctx, b.id)
+}
+
+// Lists see Client.GetAllLists.
+func (b *BoardClient) Lists(ctx context.Context) ([]GetAllList, error) {
+	return b.c.GetAllLists(ctx, b.id)
+}
+
+// NewList see Client.NewList.
+func (b *BoardClient) NewList(ctx context.Context, title string) (NewListResponse, error) {
+	return b.c.NewList(ctx, b.id, title)
+}
+
+// Swimlanes see Client.GetAllSwimlanes.
+func (b *BoardClient) Swimlanes(ctx context.Context) ([]GetAllSwimlane, error) {
+	return b.c.GetAllSwimlanes(ctx, b.id)
+}
+
+// NewSwimlane see Client.NewSwimlane.
+func (b *BoardClient) NewSwimlane(ctx context.Context, title string) (NewSwimlaneResponse, error) {
+	return b.c.NewSwimlane(ctx, b.id, title)
+}
+
+// NewCard see Client.NewCard.
+func (b *BoardClient) NewCard(ctx context.Context, listID string, request NewCardRequest) (NewCardResponse, error) {
+	return b.c.NewCard(ctx, b.id, listID, request)
+}
+
+// QuickAddCard see Client.QuickAddCard.
+func (b *BoardClient) QuickAddCard(ctx context.Context, title string) (NewCardResponse, error) {
+	return b.c.QuickAddCard(ctx, b.id, title)
+}
+
+// CustomFields see Client.GetAllCustomFields.
+func (b *BoardClient) CustomFields(ctx context.Context) ([]GetAllCustomField, error) {
+	return b.c.GetAllCustomFields(ctx, b.id)
+}
+
+// Integrations see Client.GetAllIntegrations.
+func (b *BoardClient) Integrations(ctx context.Context) ([]Integration, error) {
+	return b.c.GetAllIntegrations(ctx, b.id)
+}
+
+// Attachments see Client.GetBoardAttachments.
+func (b *BoardClient) Attachments(ctx context.Context) ([]BoardAttachment, error) {
+	return b.c.GetBoardAttachments(ctx, b.id)
+}
+
+// Activities see Client.GetBoardActivities.
+func (b *BoardClient) Activities(ctx context.Context, opts ActivitiesOptions) ([]Activity, error) {
+	return b.c.GetBoardActivities(ctx, b.id, opts)
+}
+
+// List returns a handle to the list of the board with the given id.
+func (b *BoardClient) List(listID string) *ListClient {
+	return &ListClient{c: b.c, boardID: b.id, id: listID}
+}
+
+// ExportJSON see Client.ExportJSON.
+func (b *BoardClient) ExportJSON(ctx context.Context) (json.RawMessage, error) {
+	return b.c.ExportJSON(ctx, b.id)
+}
+
+// ListClient is a handle to a single list of a board.
+// It carries the ids of the board and the list implicitly and wraps the flat methods of Client.
+type ListClient struct {
+	c       *Client
+	boardID string
+	id      string
+}
+
+// ID returns the id of the list.
+func (l *ListClient) ID() string {
+	return l.id
+}
+
+// BoardID returns the id of the list's board.
+func (l *ListClient) BoardID() string {
+	return l.boardID
+}
+
+// Get see Client.GetList.
+func (l *ListClient) Get(ctx context.Context) (GetList, error) {
+	return l.c.GetList(ctx, l.boardID, l.id)
+}
+
+// Exists see Client.ListExists.
+func (l *ListClient) Exists(ctx context.Context) (bool, error) {
+	return l.c.ListExists(ctx, l.boardID, l.id)
+}
+
+// Delete see Client.DeleteList.
+func (l *ListClient) Delete(ctx context.Context) error {
+	return l.c.DeleteList(ctx, l.boardID, l.id)
+}
+
+// Cards see Client.GetAllCards.
+func (l *ListClient) Cards(ctx context.Context) ([]GetAllCard, error) {
+	return l.c.GetAllCards(ctx, l.boardID, l.id)
+}
+
+// ArchivedCards see Client.GetArchivedCards.
+func (l *ListClient) ArchivedCards(ctx context.Context) ([]GetCard, error) {
+	return l.c.GetArchivedCards(ctx, l.boardID, l.id)
+}
+
+// NewCard see Client.NewCard.
+func (l *ListClient) NewCard(ctx context.Context, request NewCardRequest) (NewCardResponse, error) {
+	return l.c.NewCard(ctx, l.boardID, l.id, request)
+}
+
+// RebalanceSort see Client.RebalanceListSort.
+func (l *ListClient) RebalanceSort(ctx context.Context) error {
+	return l.c.RebalanceListSort(ctx, l.boardID, l.id)
+}
+
+// Card returns a handle to the card of the list with the given id.
+func (l *ListClient) Card(cardID string) *CardClient {
+	return &CardClient{c: l.c, boardID: l.boardID, listID: l.id, id: cardID}
+}
+
+// CardClient is a handle to a single card of a list.
+// It carries the ids of the board, the list and the card implicitly and wraps the
+// flat methods of Client.
+type CardClient struct {
+	c       *Client
+	boardID string
+	listID  string
+	id      string
+}
+
+// ID returns the id of the card.
+func (cc *CardClient) ID() string {
+	return cc.id
+}
+
+// Get see Client.GetCard.
+func (cc *CardClient) Get(ctx context.Context) (GetCard, error) {
+	return cc.c.GetCard(ctx, cc.boardID, cc.listID, cc.id)
+}
+
+// Exists see Client.CardExists.
+func (cc *CardClient) Exists(ctx context.Context) (bool, error) {
+	return cc.c.CardExists(ctx, cc.boardID, cc.listID, cc.id)
+}
+
+// Edit see Client.EditCard.
+func (cc *CardClient) Edit(ctx context.Context, opts EditCardOptions) (EditCardResponse, error) {
+	return cc.c.EditCard(ctx, cc.boardID, cc.listID, cc.id, opts)
+}
+
+// EditWith see Client.EditCardWith.
+func (cc *CardClient) EditWith(ctx context.Context, opts ...EditCardOption) (EditCardResponse, error) {
+	return cc.c.EditCardWith(ctx, cc.boardID, cc.listID, cc.id, opts...)
+}
+
+// Delete see Client.DeleteCard.
+func (cc *CardClient) Delete(ctx context.Context) error {
+	return cc.c.DeleteCard(ctx, cc.boardID, cc.id)
+}
+
+// MoveToTop see Client.MoveCardToTop.
+func (cc *CardClient) MoveToTop(ctx context.Context) error {
+	return cc.c.MoveCardToTop(ctx, cc.boardID, cc.listID, cc.id)
+}
+
+// MoveToBottom see Client.MoveCardToBottom.
+func (cc *CardClient) MoveToBottom(ctx context.Context) error {
+	return cc.c.MoveCardToBottom(ctx, cc.boardID, cc.listID, cc.id)
+}
+
+// SetDates see Client.SetCardDates.
+func (cc *CardClient) SetDates(ctx context.Context, dates CardDates) error {
+	return cc.c.SetCardDates(ctx, cc.boardID, cc.listID, cc.id, dates)
+}
+
+// CustomFieldValue see Client.GetCardCustomFieldValue.
+func (cc *CardClient) CustomFieldValue(ctx context.Context, customFieldID string) (any, bool, error) {
+	return cc.c.GetCardCustomFieldValue(ctx, cc.boardID, cc.listID, cc.id, customFieldID)
+}
+
+// SetCustomFieldValue see Client.SetCardCustomFieldValue.
+func (cc *CardClient) SetCustomFieldValue(ctx context.Context, customFieldID string, value any) error {
+	return cc.c.SetCardCustomFieldValue(ctx, cc.boardID, cc.listID, cc.id, customFieldID, value)
+}
+
+// Activities see Client.GetCardActivities.
+func (cc *CardClient) Activities(ctx context.Context, opts ActivitiesOptions) ([]Activity, error) {
+	return cc.c.GetCardActivities(ctx, cc.boardID, cc.id, opts)
+}
+
+// Comments see Client.GetAllComments.
+func (cc *CardClient) Comments(ctx context.Context) ([]GetAllComment, error) {
+	return cc.c.GetAllComments(ctx, cc.boardID, cc.id)
+}
+
+// NewComment adds a comment authored by the current user, see Client.NewComment.
+func (cc *CardClient) NewComment(ctx context.Context, comment string) (NewCommentResponse, error) {
+	return cc.c.NewComment(ctx, cc.boardID, cc.id, NewCommentRequest{
+		AuthorID: cc.c.GetCurrentUserID(),
+		Comment:  comment,
+	})
+}
+
+// Checklists see Client.GetAllChecklists.
+func (cc *CardClient) Checklists(ctx context.Context) ([]GetAllChecklist, error) {
+	return cc.c.GetAllChecklists(ctx, cc.boardID, cc.id)
+}
+
+// NewChecklist see Client.NewChecklist.
+func (cc *CardClient) NewChecklist(ctx context.Context, data NewChecklistRequest) (NewChecklistResponse, error) {
+	return cc.c.NewChecklist(ctx, cc.boardID, cc.id, data)
+}
+
+// AddChecklistItems see Client.AddChecklistItems.
+func (cc *CardClient) AddChecklistItems(ctx context.Context, checklistID string, titles []string) ([]string, error) {
+	return cc.c.AddChecklistItems(ctx, cc.boardID, cc.id, checklistID, titles)
+}
@@ -10,6 +10,7 @@ package wego
 import (
 	"context"
 	"errors"
+	"fmt"
 	"io"
 )
 
@@ -49,6 +50,57 @@ func (c *Client) NewIntegration(ctx context.Context, boardID, url string) (r New
 	return
 }
 
+// NewIntegrationWithOptions creates an integration and configures it with the given options.
+// The Url of opts is mandatory. If opts.Enabled is nil, the integration is enabled.
+//
+// Note: The new_integration route only stores the url of an integration, therefore it is
+// created disabled, configured with an edit_integration request and enabled with another one,
+// which takes three requests. Servers that ignore the enabled flag on creation use their
+// default configuration until the second request has been processed.
+// If configuring the integration fails, it stays disabled and its id is returned with the error.
+func (c *Client) NewIntegrationWithOptions(ctx context.Context, boardID string, opts EditIntegrationOptions) (r NewIntegrationResponse, err error) {
+	endpoint := c.endpoint("boards", boardID, "integrations")
+
+	if opts.Url == nil || *opts.Url == "" {
+		err = errors.New("integration url is mandatory")
+		return
+	}
+	err = validateActivityFilter(opts.Activities)
+	if err != nil {
+		return
+	}
+
+	req, err := c.newAuthenticatedPOSTRequest(ctx, endpoint, EditIntegrationOptions{Url: opts.Url, Enabled: Ptr(false)})
+	if err != nil {
+		return
+	}
+
+	err = c.doSimpleRequest(req, &r)
+	if err != nil {
+		return
+	}
+
+	// Configure the integration, while it is disabled.
+	config := opts
+	config.Url = nil
+	config.Enabled = Ptr(false)
+	err = c.EditIntegration(ctx, boardID, r.ID, config)
+	if err != nil {
+		err = fmt.Errorf("failed to configure integration '%s': %w", r.ID, err)
+		return
+	}
+
+	if opts.Enabled == nil || *opts.Enabled {
+		err = c.EditIntegration(ctx, boardID, r.ID, EditIntegrationOptions{Enabled: Ptr(true)})
+		if err != nil {
+			err = fmt.Errorf("failed to enable integration '%s': %w", r.ID, err)
+			return
+		}
+	}
+
+	return
+}
+
 // GetIntegration performs a get_integration request against the Wekan server.
 // See https://wekan.github.io/api/v5.13/#get_integration
 //
@@ -77,6 +129,11 @@ func (c *Client) GetIntegration(ctx context.Context, boardID, integrationID stri
 func (c *Client) EditIntegration(ctx context.Context, boardID, integrationID string, data EditIntegrationOptions) (err error) {
 	endpoint := c.endpoint("boards", boardID, "integrations", integrationID)
 
+	err = validateActivityFilter(data.Activities)
+	if err != nil {
+		return
+	}
+
 	req, err := c.newAuthenticatedPUTRequest(ctx, endpoint, data)
 	if err != nil {
 		return
@@ -113,9 +170,16 @@ func (c *Client) DeleteIntegrationActivities(ctx context.Context, boardID, integ
 
 // NewIntegrationActivities performs a new_integration_activities request against the Wekan server.
 // See https://wekan.github.io/api/v5.13/#new_integration_activities
-func (c *Client) NewIntegrationActivities(ctx context.Context, boardID, integrationID string, activities []string) (integration Integration, err error) {
+//
+// Returns an error, if an activity type is unknown.
+func (c *Client) NewIntegrationActivities(ctx context.Context, boardID, integrationID string, activities []ActivityType) (integration Integration, err error) {
 	endpoint := c.endpoint("boards", boardID, "integrations", integrationID, "activities")
 
+	err = validateActivityFilter(activities)
+	if err != nil {
+		return
+	}
+
 	req, err := c.newAuthenticatedPOSTRequest(ctx, endpoint, newIntegrationActivitiesRequest{Activities: activities})
 	if err != nil {
 		return
@@ -134,16 +198,16 @@ func (c *Client) NewIntegrationActivities(ctx context.Context, boardID, integrat
 //#############//
 
 type Integration struct {
-	Enabled    bool     `json:"enabled"`
-	Title      string   `json:"title"`
-	Type       string   `json:"type"`
-	Activities []string `json:"activities"`
-	Url        string   `json:"url"`
-	Token      string   `json:"token"`
-	BoardID    string   `json:"boardId"`
-	CreatedAt  string   `json:"createdAt"`
-	ModifiedAt string   `json:"modifiedAt"`
-	UserID     string   `json:"userId"`
+	Enabled    bool           `json:"enabled"`
+	Title      string         `json:"title"`
+	Type       string         `json:"type"`
+	Activities []ActivityType `json:"activities"`
+	Url        string         `json:"url"`
+	Token      string         `json:"token"`
+	BoardID    string         `json:"boardId"`
+	CreatedAt  string         `json:"createdAt"`
+	ModifiedAt string         `json:"modifiedAt"`
+	UserID     string         `json:"userId"`
 }
 
 type newIntegrationRequest struct {
@@ -154,14 +218,16 @@ type NewIntegrationResponse struct {
 	ID string `json:"_id"`
 }
 
+// EditIntegrationOptions are the options of an edit_integration request.
+// Nil fields are omitted and remain unchanged on the server.
 type EditIntegrationOptions struct {
-	Enabled    bool     `json:"enabled"`
-	Title      string   `json:"title"`
-	Url        string   `json:"url"`
-	Token      string   `json:"token"`
-	Activities []string `json:"activities"`
+	Enabled    *bool          `json:"enabled,omitempty"`
+	Title      *string        `json:"title,omitempty"`
+	Url        *string        `json:"url,omitempty"`
+	Token      *string        `json:"token,omitempty"`
+	Activities []ActivityType `json:"activities,omitempty"`
 }
 
 type newIntegrationActivitiesRequest struct {
-	Activities []string `json:"activities"`
+	Activities []ActivityType `json:"activities"`
 }
@@ -0,0 +1,63 @@
+/**
+ * Copyright (c) 2023 Sebastian Borchers
+ *
+ * This software is released under the MIT License.
+ * https://opensource.org/licenses/MIT
+ */
+
+package wego
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestEditIntegrationOptionsMarshalOmitsUnsetFields(t *testing.T) {
+	assertJSON(t, EditIntegrationOptions{}, `{}`)
+	assertJSON(t, EditIntegrationOptions{Enabled: Ptr(false)}, `{"enabled":false}`)
+	assertJSON(t, EditIntegrationOptions{Title: Ptr(""), Token: Ptr("secret")}, `{"title":"","token":"secret"}`)
+	assertJSON(t, EditIntegrationOptions{Url: Ptr("https://example.com")}, `{"url":"https://example.com"}`)
+}
+
+func TestNewIntegrationWithOptionsConfiguresBeforeEnabling(t *testing.T) {
+	var (
+		mx       sync.Mutex
+		requests []string
+	)
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		mx.Lock()
+		requests = append(requests, r.Method+" "+r.URL.Path+" "+string(body))
+		mx.Unlock()
+
+		writeJSON(w, NewIntegrationResponse{ID: "i"})
+	})
+	c := newTestClient(t, srv, Options{})
+
+	r, err := c.NewIntegrationWithOptions(context.Background(), "b", EditIntegrationOptions{
+		Url:        Ptr("https://example.com/hook"),
+		Token:      Ptr("secret"),
+		Activities: []ActivityType{ActivityTypeCreateCard},
+	})
+	if err != nil {
+		t.Fatal(err)
+	} else if r.ID != "i" {
+		t.Fatalf("expected integration id 'i', got '%s'", r.ID)
+	}
+
+	want := []string{
+		`POST /api/boards/b/integrations {"enabled":false,"url":"https://example.com/hook"}`,
+		`PUT /api/boards/b/integrations/i {"enabled":false,"token":"secret","activities":["createCard"]}`,
+		`PUT /api/boards/b/integrations/i {"enabled":true}`,
+	}
+	mx.Lock()
+	defer mx.Unlock()
+	if !reflect.DeepEqual(requests, want) {
+		t.Fatalf("expected requests\n%q\ngot\n%q", want, requests)
+	}
+}
@@ -10,7 +10,10 @@ package wego
 import (
 	"context"
 	"errors"
+	"fmt"
 	"io"
+	"sort"
+	"sync"
 )
 
 // GetAllLists performs a get_all_lists request against the Wekan server.
@@ -31,6 +34,79 @@ func (c *Client) GetAllLists(ctx context.Context, boardID string) (lists []GetAl
 	return
 }
 
+// GetAllListsSorted returns all lists of the board ordered by their Sort value, which is
+// the order of the columns shown by Wekan. Lists with equal sort values keep their order.
+//
+// Note: Depending on the Wekan version, the get_all_lists response lacks the sort values.
+// In this case, every list is fetched with GetList concurrently to obtain them,
+// see Options.MaxConcurrency.
+func (c *Client) GetAllListsSorted(ctx context.Context, boardID string) (lists []GetAllList, err error) {
+	lists, err = c.GetAllLists(ctx, boardID)
+	if err != nil {
+		return
+	}
+
+	if !hasListSortValues(lists) {
+		err = c.forEachConcurrent(ctx, len(lists), func(ctx context.Context, i int) error {
+			l, err := c.GetList(ctx, boardID, lists[i].ID)
+			if err != nil {
+				return fmt.Errorf("list '%s': %w", lists[i].ID, err)
+			}
+
+			lists[i].Sort = float64(l.Sort)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	sort.SliceStable(lists, func(i, j int) bool {
+		return lists[i].Sort < lists[j].Sort
+	})
+	return
+}
+
+// GetAllListsMulti performs get_all_lists requests for multiple boards concurrently,
+// with at most Options.MaxConcurrency requests in flight.
+//
+// The lists of the successfully fetched boards are always returned.
+// If some boards failed, a *BatchError is returned, keyed by the index of the board in boardIDs.
+func (c *Client) GetAllListsMulti(ctx context.Context, boardIDs []string) (lists map[string][]GetAllList, err error) {
+	var mx sync.Mutex
+	lists = make(map[string][]GetAllList, len(boardIDs))
+
+	err = c.forEachConcurrent(ctx, len(boardIDs), func(ctx context.Context, i int) error {
+		bl, err := c.GetAllLists(ctx, boardIDs[i])
+		if err != nil {
+			return fmt.Errorf("board '%s': %w", boardIDs[i], err)
+		}
+
+		mx.Lock()
+		lists[boardIDs[i]] = bl
+		mx.Unlock()
+		return nil
+	})
+	return
+}
+
+// CanSetWipLimit reports, whether the list holds at most limit cards, so that a WIP limit
+// of limit can be enabled for it without the list exceeding it right away.
+// Archived cards are not counted. Limits lower than 1 are never valid.
+// It performs a single get_all_cards request.
+func (c *Client) CanSetWipLimit(ctx context.Context, boardID, listID string, limit int) (ok bool, err error) {
+	if limit < 1 {
+		return false, nil
+	}
+
+	cards, err := c.GetAllCards(ctx, boardID, listID)
+	if err != nil {
+		return
+	}
+
+	return len(cards) <= limit, nil
+}
+
 // NewList performs a new_list request against the Wekan server.
 // See https://wekan.github.io/api/v5.13/#new_list
 func (c *Client) NewList(ctx context.Context, boardID, title string) (r NewListResponse, err error) {
@@ -72,6 +148,12 @@ func (c *Client) GetList(ctx context.Context, boardID, listID string) (list GetL
 	return
 }
 
+// ListExists reports, whether the list exists.
+// It performs a single get_list request.
+func (c *Client) ListExists(ctx context.Context, boardID, listID string) (bool, error) {
+	return c.exists(ctx, c.endpoint("boards", boardID, "lists", listID))
+}
+
 // DeleteList performs a delete_list request against the Wekan server.
 // See https://wekan.github.io/api/v5.13/#delete_list
 func (c *Client) DeleteList(ctx context.Context, boardID, listID string) (err error) {
@@ -85,6 +167,21 @@ func (c *Client) DeleteList(ctx context.Context, boardID, listID string) (err er
 	return c.doSimpleRequest(req, nil)
 }
 
+//################//
+//### Internal ###//
+//################//
+
+// hasListSortValues reports, whether the server sent the sort values of the lists.
+// Since Wekan numbers the lists from 0, the values are missing, if all of them are 0.
+func hasListSortValues(lists []GetAllList) bool {
+	for _, l := range lists {
+		if l.Sort != 0 {
+			return true
+		}
+	}
+	return len(lists) <= 1
+}
+
 //#############//
 //### Types ###//
 //#############//
@@ -92,6 +189,8 @@ func (c *Client) DeleteList(ctx context.Context, boardID, listID string) (err er
 type GetAllList struct {
 	ID    string `json:"_id"`
 	Title string `json:"title"`
+	// The position of the list on the board. See GetAllListsSorted.
+	Sort float64 `json:"sort"`
 }
 
 type newListRequest struct {
@@ -115,7 +214,21 @@ type GetList struct {
 	ModifiedAt string       `json:"modifiedAt"`
 	WipLimit   ListWIPLimit `json:"wipLimit"`
 	Color      string       `json:"color"`
-	Type       string       `json:"type"`
+	Type       ListType     `json:"type"`
+}
+
+// ListType is the type of a list.
+// Wekan may report types that have no constant in this package.
+type ListType string
+
+const (
+	ListTypeList     ListType = "list"
+	ListTypeTemplate ListType = "template-list"
+)
+
+// IsTemplate reports, whether the list is a template.
+func (t ListType) IsTemplate() bool {
+	return t == ListTypeTemplate
 }
 
 type ListWIPLimit struct {
@@ -9,11 +9,15 @@ package wego
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
 	"strings"
 	"time"
+
+	"github.com/rs/zerolog/log"
 )
 
 // Login performs a login request against the Wekan server.
@@ -21,11 +25,18 @@ import (
 //
 // Note: The client ensures to authenticate against the API on its own.
 // It is not required to call this method for normal usage.
+//
+// Note: The request is sent to RemoteAddr + "/users/login" (see Options.LoginPath),
+// not below "/api" like all other requests. This matches the Wekan API, which serves
+// its login and register routes outside of the REST API's path.
 func (c *Client) Login(ctx context.Context, username, password string) (r LoginResponse, err error) {
-	const endpoint = "/users/login"
+	endpoint := c.opts.LoginPath
 
 	// Create the url encoded params.
 	params := url.Values{}
+	for k, v := range c.opts.ExtraLoginParams {
+		params[k] = v
+	}
 	params.Set("username", username)
 	params.Set("password", password)
 
@@ -34,8 +45,10 @@ func (c *Client) Login(ctx context.Context, username, password string) (r LoginR
 
 // Register performs a register request against the Wekan server.
 // See https://wekan.github.io/api/v5.13/#register
+//
+// Note: Like Login, the request is sent outside of "/api", see Options.RegisterPath.
 func (c *Client) Register(ctx context.Context, username, password, email string) (r LoginResponse, err error) {
-	const endpoint = "/users/register"
+	endpoint := c.opts.RegisterPath
 
 	// Create the url encoded params.
 	params := url.Values{}
@@ -43,13 +56,66 @@ func (c *Client) Register(ctx context.Context, username, password, email string)
 	params.Set("password", password)
 	params.Set("email", email)
 
+	if c.opts.DryRun {
+		log.Info().Str("method", http.MethodPost).Str("path", endpoint).Msg("dry run: request not sent")
+		return
+	}
+
 	return c.loginOrRegister(ctx, endpoint, params)
 }
 
+// CheckCredentials performs a single login request against the Wekan server at remoteAddr
+// to check, whether the given credentials are valid.
+// Unlike NewClient, no client is created, no routines are started and the login is not
+// retried. This is handy to validate a configuration on startup.
+// Returns an error matching ErrInvalidCredentials, if the server rejected the credentials,
+// or one matching ErrNetwork, if the server could not be reached.
+func CheckCredentials(ctx context.Context, remoteAddr, username, password string) error {
+	return CheckCredentialsWithClient(ctx, nil, remoteAddr, username, password)
+}
+
+// CheckCredentialsWithClient is like CheckCredentials, but sends the login request with
+// the given http client. If httpc is nil, a default client is used.
+func CheckCredentialsWithClient(ctx context.Context, httpc *http.Client, remoteAddr, username, password string) error {
+	if httpc == nil {
+		httpc = &http.Client{}
+	}
+
+	c := &Client{
+		opts: Options{
+			RemoteAddr:         normalizeRemoteAddr(remoteAddr),
+			LoginPath:          defaultLoginPath,
+			Timeout:            defaultTimeout,
+			TokenExpiresFormat: time.RFC3339,
+			DefaultTokenTTL:    defaultTokenTTL,
+			RequestIDHeader:    defaultRequestIDHeader,
+		},
+		httpc: httpc,
+	}
+
+	_, err := c.Login(ctx, username, password)
+	if err != nil {
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && isInvalidCredentialsStatus(apiErr.StatusCode) {
+			return fmt.Errorf("%w: %w", ErrInvalidCredentials, err)
+		}
+		return err
+	}
+
+	return nil
+}
+
 //################//
 //### Internal ###//
 //################//
 
+// isInvalidCredentialsStatus reports, whether a login response with the given status code
+// indicates that the credentials were rejected. Depending on its version, Wekan responds
+// with either of them.
+func isInvalidCredentialsStatus(code int) bool {
+	return code == http.StatusBadRequest || code == http.StatusUnauthorized || code == http.StatusForbidden
+}
+
 // loginOrRegister is an internal helper that performs a login or register request, since they
 // are almost the same in the Wekan API.
 func (c *Client) loginOrRegister(ctx context.Context, endpoint string, params url.Values) (r LoginResponse, err error) {
@@ -61,35 +127,41 @@ func (c *Client) loginOrRegister(ctx context.Context, endpoint string, params ur
 	}
 	req.Header.Set("Content-Type", mimeURL)
 	req.Header.Set("Accept", mimeJSON)
-	resp, err := c.httpc.Do(req)
+	c.setCommonHeaders(ctx, req)
+
+	req, cancel := c.withTimeout(req)
+	defer cancel()
+
+	resp, err := c.send(req)
 	if err != nil {
-		err = fmt.Errorf("failed to send POST request: %v", err)
+		err = c.withRequestID(req, sendError(req, err))
 		return
-	} else if resp.StatusCode == http.StatusBadRequest {
-		var respData badRequestResponse
-		err = parseResponse(resp, &respData)
-		if err != nil {
-			err = fmt.Errorf("failed to parse response of bad request: %v", err)
-			return
-		}
+	}
+	defer closeBody(resp.Body)
 
-		err = fmt.Errorf("bad request: %s (%d)", respData.Reason, respData.Error)
-		return
-	} else if resp.StatusCode != http.StatusOK {
-		err = fmt.Errorf("unexpected status code '%d' received", resp.StatusCode)
+	if resp.StatusCode != http.StatusOK {
+		err = c.withRequestID(req, c.newAPIError(resp))
 		return
 	}
 
 	// Parse the response.
+	var raw json.RawMessage
+	err = c.parseResponse(resp, &raw)
+	if err != nil {
+		err = fmt.Errorf("failed to parse response: %v", err)
+		return
+	}
+
 	var respData loginResponse
-	err = parseResponse(resp, &respData)
+	err = json.Unmarshal(raw, &respData)
 	if err != nil {
 		err = fmt.Errorf("failed to parse response: %v", err)
 		return
 	}
 
 	// Load the response into our public type.
-	r.load(respData)
+	err = r.load(respData, c.opts.TokenExpiresFormat, c.opts.DefaultTokenTTL)
+	r.Raw = raw
 	return
 }
 
@@ -97,31 +169,72 @@ func (c *Client) loginOrRegister(ctx context.Context, endpoint string, params ur
 //### Types ###//
 //#############//
 
-type badRequestResponse struct {
-	Error  int    `json:"error"`
-	Reason string `json:"reason"`
-}
-
 type loginResponse struct {
-	ID           string `json:"id"`
-	Token        string `json:"token"`
-	TokenExpires string `json:"tokenExpires"`
+	ID           string          `json:"id"`
+	Token        string          `json:"token"`
+	TokenType    string          `json:"tokenType"`
+	TokenExpires json.RawMessage `json:"tokenExpires"`
 }
 
 type LoginResponse struct {
-	ID           string
-	Token        string
+	ID    string
+	Token string
+	// The scheme the token must be sent with in the Authorization header.
+	// Wekan does not report it, therefore it defaults to "Bearer".
+	TokenType    string
 	TokenExpires time.Time
+
+	// The complete response of the server, including fields that are not decoded.
+	Raw json.RawMessage
 }
 
-func (r *LoginResponse) load(l loginResponse) (err error) {
+// The default scheme of API tokens, see LoginResponse.TokenType.
+const defaultTokenType = "Bearer"
+
+// load loads l into r. The expiry of the token is parsed with the given layout.
+// If it can not be parsed, the token is assumed to expire after defaultTTL.
+func (r *LoginResponse) load(l loginResponse, layout string, defaultTTL time.Duration) (err error) {
 	r.ID = l.ID
 	r.Token = l.Token
+	r.TokenType = l.TokenType
+	if r.TokenType == "" {
+		r.TokenType = defaultTokenType
+	}
 
-	r.TokenExpires, err = time.Parse(time.RFC3339, l.TokenExpires)
+	r.TokenExpires, err = parseTokenExpires(l.TokenExpires, layout)
 	if err != nil {
-		return fmt.Errorf("failed to parse token expires time stamp: %v", err)
+		if defaultTTL <= 0 {
+			return fmt.Errorf("failed to parse token expires time stamp: %v", err)
+		}
+
+		log.Warn().Err(err).Str("tokenExpires", string(l.TokenExpires)).Dur("defaultTTL", defaultTTL).Msg("login: failed to parse token expires time stamp, using default TTL")
+		r.TokenExpires = time.Now().Add(defaultTTL)
 	}
 
 	return nil
 }
+
+// parseTokenExpires parses the expiry of a token, which Wekan sends in one of the forms
+//   - a time stamp string with the given layout,
+//   - a number of milliseconds since the Unix epoch,
+//   - an EJSON date object, i.e. {"$date": <one of the above>}.
+func parseTokenExpires(data json.RawMessage, layout string) (t time.Time, err error) {
+	var s string
+	if err = json.Unmarshal(data, &s); err == nil {
+		return time.Parse(layout, s)
+	}
+
+	var ms int64
+	if err = json.Unmarshal(data, &ms); err == nil {
+		return time.UnixMilli(ms), nil
+	}
+
+	var date struct {
+		Date json.RawMessage `json:"$date"`
+	}
+	if err = json.Unmarshal(data, &date); err == nil && len(date.Date) > 0 {
+		return parseTokenExpires(date.Date, layout)
+	}
+
+	return time.Time{}, fmt.Errorf("unsupported format: %s", string(data))
+}
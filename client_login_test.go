@@ -0,0 +1,145 @@
+/**
+ * Copyright (c) 2023 Sebastian Borchers
+ *
+ * This software is released under the MIT License.
+ * https://opensource.org/licenses/MIT
+ */
+
+package wego
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+// newLoginRecorder starts a fake Wekan server, that records the paths of all requests.
+// Requests to any of the given login paths succeed, all others fail with 404.
+func newLoginRecorder(t *testing.T, loginPaths ...string) (srv *httptest.Server, paths func() []string) {
+	t.Helper()
+
+	var (
+		mx       sync.Mutex
+		recorded []string
+	)
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mx.Lock()
+		recorded = append(recorded, r.URL.Path)
+		mx.Unlock()
+
+		for _, p := range loginPaths {
+			if r.URL.Path == p {
+				writeLoginResponse(w)
+				return
+			}
+		}
+		http.NotFound(w, r)
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv, func() []string {
+		mx.Lock()
+		defer mx.Unlock()
+		return append([]string(nil), recorded...)
+	}
+}
+
+func TestLoginURLIsOutsideAPI(t *testing.T) {
+	for _, suffix := range []string{"", "/", "/api", "/api/"} {
+		suffix := suffix
+		t.Run("RemoteAddr"+suffix, func(t *testing.T) {
+			srv, paths := newLoginRecorder(t, "/users/login")
+
+			newTestClient(t, srv, Options{RemoteAddr: srv.URL + suffix})
+
+			got := paths()
+			if len(got) != 1 || got[0] != "/users/login" {
+				t.Fatalf("expected a single login request to '/users/login', got %q", got)
+			}
+		})
+	}
+}
+
+func TestCustomLoginAndRegisterPaths(t *testing.T) {
+	const (
+		loginPath    = "/auth/login"
+		registerPath = "/auth/register"
+	)
+
+	t.Run("login", func(t *testing.T) {
+		srv, paths := newLoginRecorder(t, loginPath)
+
+		newTestClient(t, srv, Options{LoginPath: loginPath})
+
+		got := paths()
+		if len(got) != 1 || got[0] != loginPath {
+			t.Fatalf("expected a single login request to '%s', got %q", loginPath, got)
+		}
+	})
+
+	t.Run("register", func(t *testing.T) {
+		srv, paths := newLoginRecorder(t, loginPath, registerPath)
+
+		c := newTestClient(t, srv, Options{LoginPath: loginPath, RegisterPath: registerPath})
+
+		r, err := c.Register(context.Background(), "new-user", "password", "new-user@example.com")
+		if err != nil {
+			t.Fatal(err)
+		} else if r.Token != testToken {
+			t.Fatalf("expected token '%s', got '%s'", testToken, r.Token)
+		}
+
+		got := paths()
+		if len(got) != 2 || got[1] != registerPath {
+			t.Fatalf("expected a register request to '%s', got %q", registerPath, got)
+		}
+	})
+}
+
+func TestExtraLoginParams(t *testing.T) {
+	var (
+		mx   sync.Mutex
+		form url.Values
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != defaultLoginPath {
+			http.NotFound(w, r)
+			return
+		}
+
+		r.ParseForm()
+		mx.Lock()
+		form = r.PostForm
+		mx.Unlock()
+		writeLoginResponse(w)
+	}))
+	defer srv.Close()
+
+	newTestClient(t, srv, Options{
+		Username: "user",
+		Password: "password",
+		ExtraLoginParams: url.Values{
+			"domain":   {"ldap"},
+			"scopes":   {"a", "b"},
+			"username": {"other-user"},
+			"password": {"other-password"},
+		},
+	})
+
+	mx.Lock()
+	defer mx.Unlock()
+
+	want := url.Values{
+		"domain":   {"ldap"},
+		"scopes":   {"a", "b"},
+		"username": {"user"},
+		"password": {"password"},
+	}
+	if !reflect.DeepEqual(form, want) {
+		t.Fatalf("expected login form %v, got %v", want, form)
+	}
+}
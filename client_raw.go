@@ -0,0 +1,38 @@
+/**
+ * Copyright (c) 2023 Sebastian Borchers
+ *
+ * This software is released under the MIT License.
+ * https://opensource.org/licenses/MIT
+ */
+
+package wego
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+)
+
+// GetRaw performs an authenticated GET request on the given path and returns the
+// unparsed response body. The path is relative to RemoteAddr, e.g. "/api/boards/<boardID>".
+// This is an escape hatch meant for debugging, e.g. to inspect a response that fails to
+// decode into the typed result of a method. The body is neither validated nor normalized,
+// but Options.MaxResponseBytes applies and responses with an HTML content type fail
+// with ErrHTMLResponse.
+func (c *Client) GetRaw(ctx context.Context, path string) (body json.RawMessage, err error) {
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+
+	req, err := c.newAuthenticatedGETRequest(ctx, path)
+	if err != nil {
+		return
+	}
+
+	err = c.doStreamRequest(req, func(r io.Reader) (err error) {
+		body, err = c.readResponse(r)
+		return
+	})
+	return
+}
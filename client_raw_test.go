@@ -0,0 +1,56 @@
+/**
+ * Copyright (c) 2023 Sebastian Borchers
+ *
+ * This software is released under the MIT License.
+ * https://opensource.org/licenses/MIT
+ */
+
+package wego
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestGetRaw(t *testing.T) {
+	const maxBytes = 256
+
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/small":
+			w.Header().Set("Content-Type", mimeJSON)
+			fmt.Fprint(w, `{"_id":"b"}`)
+		case "/api/large":
+			w.Header().Set("Content-Type", mimeJSON)
+			fmt.Fprintf(w, `{"title":"%s"}`, strings.Repeat("x", maxBytes))
+		case "/api/html":
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			fmt.Fprint(w, `<html></html>`)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	c := newTestClient(t, srv, Options{MaxResponseBytes: maxBytes})
+	ctx := context.Background()
+
+	body, err := c.GetRaw(ctx, "api/small")
+	if err != nil {
+		t.Fatal(err)
+	} else if string(body) != `{"_id":"b"}` {
+		t.Fatalf("unexpected body: %s", body)
+	}
+
+	_, err = c.GetRaw(ctx, "/api/large")
+	if !errors.Is(err, ErrResponseTooLarge) {
+		t.Fatalf("expected ErrResponseTooLarge, got: %v", err)
+	}
+
+	_, err = c.GetRaw(ctx, "/api/html")
+	if !errors.Is(err, ErrHTMLResponse) {
+		t.Fatalf("expected ErrHTMLResponse, got: %v", err)
+	}
+}
@@ -9,7 +9,9 @@ package wego
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 )
 
@@ -31,6 +33,33 @@ func (c *Client) GetAllSwimlanes(ctx context.Context, boardID string) (swimlanes
 	return
 }
 
+// GetArchivedSwimlanes returns all archived swimlanes of the board.
+// It performs a single board export request (see ExportJSON) and picks the archived
+// swimlanes from it, since get_all_swimlanes omits them. The export grows with the board.
+func (c *Client) GetArchivedSwimlanes(ctx context.Context, boardID string) (swimlanes []GetSwimlane, err error) {
+	boardJSON, err := c.ExportJSON(ctx, boardID)
+	if err != nil {
+		return
+	}
+
+	var export struct {
+		Swimlanes []GetSwimlane `json:"swimlanes"`
+	}
+	err = json.Unmarshal(boardJSON, &export)
+	if err != nil {
+		err = fmt.Errorf("failed to unmarshal board export: %v", err)
+		return
+	}
+
+	for _, sl := range export.Swimlanes {
+		if sl.Archived {
+			swimlanes = append(swimlanes, sl)
+		}
+	}
+
+	return
+}
+
 // NewSwimlane performs a new_swimlane request against the Wekan server.
 // See https://wekan.github.io/api/v5.13/#new_swimlane
 func (c *Client) NewSwimlane(ctx context.Context, boardID, title string) (r NewSwimlaneResponse, err error) {
@@ -103,14 +132,31 @@ type NewSwimlaneResponse struct {
 }
 
 type GetSwimlane struct {
-	Title      string `json:"title"`
-	Archived   bool   `json:"archived"`
-	ArchivedAt string `json:"archivedAt"`
-	BoardID    string `json:"boardId"`
-	CreatedAt  string `json:"createdAt"`
-	Sort       int    `json:"sort"`
-	Color      string `json:"color"`
-	UpdatedAt  string `json:"updatedAt"`
-	ModifiedAt string `json:"modifiedAt"`
-	Type       string `json:"type"`
+	ID         string       `json:"_id"`
+	Title      string       `json:"title"`
+	Archived   bool         `json:"archived"`
+	ArchivedAt string       `json:"archivedAt"`
+	BoardID    string       `json:"boardId"`
+	CreatedAt  string       `json:"createdAt"`
+	Sort       int          `json:"sort"`
+	Color      string       `json:"color"`
+	UpdatedAt  string       `json:"updatedAt"`
+	ModifiedAt string       `json:"modifiedAt"`
+	Type       SwimlaneType `json:"type"`
+}
+
+// SwimlaneType is the type of a swimlane.
+// Wekan may report types that have no constant in this package.
+type SwimlaneType string
+
+const (
+	SwimlaneTypeSwimlane SwimlaneType = "swimlane"
+	SwimlaneTypeTemplate SwimlaneType = "template-swimlane"
+	// The swimlanes of a template board, which hold the card, list and board templates.
+	SwimlaneTypeTemplateContainer SwimlaneType = "template-container"
+)
+
+// IsTemplate reports, whether the swimlane is a template or holds templates.
+func (t SwimlaneType) IsTemplate() bool {
+	return t == SwimlaneTypeTemplate || t == SwimlaneTypeTemplateContainer
 }
@@ -0,0 +1,268 @@
+/**
+ * Copyright (c) 2023 Sebastian Borchers
+ *
+ * This software is released under the MIT License.
+ * https://opensource.org/licenses/MIT
+ */
+
+package wego
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/desertbit/closer/v3"
+)
+
+// The API token issued by the login route of test servers.
+const testToken = "test-token"
+
+// newTestServer starts a fake Wekan server, whose login route always succeeds.
+// All other requests are passed to h.
+func newTestServer(t *testing.T, h http.HandlerFunc) *httptest.Server {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == defaultLoginPath {
+			writeLoginResponse(w)
+			return
+		}
+		h(w, r)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// newTestClient creates a client that is logged in to srv.
+// RemoteAddr defaults to the address of srv.
+func newTestClient(t *testing.T, srv *httptest.Server, opts Options) *Client {
+	t.Helper()
+
+	if opts.RemoteAddr == "" {
+		opts.RemoteAddr = srv.URL
+	}
+	if opts.Username == "" {
+		opts.Username = "user"
+	}
+	if opts.Password == "" {
+		opts.Password = "password"
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	c, err := NewClientContext(ctx, opts)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	t.Cleanup(func() { c.Close_() })
+	return c
+}
+
+// writeLoginResponse writes a successful login response issuing testToken.
+func writeLoginResponse(w http.ResponseWriter) {
+	writeJSON(w, map[string]string{
+		"id":           "user-id",
+		"token":        testToken,
+		"tokenExpires": time.Now().Add(time.Hour).Format(time.RFC3339),
+	})
+}
+
+// writeJSON writes v as JSON response.
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", mimeJSON)
+	json.NewEncoder(w).Encode(v)
+}
+
+// trackingTransport is an http.RoundTripper that records the response bodies it returns,
+// so that tests can check whether they have been closed.
+type trackingTransport struct {
+	mx     sync.Mutex
+	bodies []*trackingBody
+}
+
+func (t *trackingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	r, err := http.DefaultTransport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	b := &trackingBody{ReadCloser: r.Body}
+	r.Body = b
+
+	t.mx.Lock()
+	t.bodies = append(t.bodies, b)
+	t.mx.Unlock()
+	return r, nil
+}
+
+// unclosed returns the number of recorded bodies that have not been closed.
+func (t *trackingTransport) unclosed() (n int) {
+	t.mx.Lock()
+	defer t.mx.Unlock()
+
+	for _, b := range t.bodies {
+		if !b.closed.Load() {
+			n++
+		}
+	}
+	return
+}
+
+type trackingBody struct {
+	io.ReadCloser
+	closed atomic.Bool
+}
+
+func (b *trackingBody) Close() error {
+	b.closed.Store(true)
+	return b.ReadCloser.Close()
+}
+
+// errorStatuses are the error status codes tests exercise.
+var errorStatuses = []int{
+	http.StatusBadRequest,
+	http.StatusUnauthorized,
+	http.StatusNotFound,
+	http.StatusConflict,
+	http.StatusInternalServerError,
+	http.StatusServiceUnavailable,
+}
+
+// writeErrorStatus writes an error response with the given status code and a JSON body,
+// like the ones sent by Wekan.
+func writeErrorStatus(w http.ResponseWriter, code int) {
+	w.Header().Set("Content-Type", mimeJSON)
+	w.WriteHeader(code)
+	fmt.Fprintf(w, `{"error":%d,"reason":"%s"}`, code, http.StatusText(code))
+}
+
+// newBlockingLoginServer starts a fake Wekan server, whose login route blocks until the
+// request is canceled or the test ends.
+func newBlockingLoginServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	}))
+	t.Cleanup(srv.Close)
+	// Runs before srv.Close, which waits for the pending handlers.
+	t.Cleanup(func() { close(release) })
+	return srv
+}
+
+func TestNewClientReturnsErrClosedWhenClosedDuringLogin(t *testing.T) {
+	srv := newBlockingLoginServer(t)
+
+	cl := closer.New()
+	time.AfterFunc(50*time.Millisecond, func() { cl.Close_() })
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := NewClient(Options{RemoteAddr: srv.URL, Username: "user", Password: "password", Closer: cl})
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, closer.ErrClosed) {
+			t.Fatalf("expected closer.ErrClosed, got: %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("NewClient did not return after the closer has been closed")
+	}
+}
+
+func TestNewClientContextCanceledDuringLogin(t *testing.T) {
+	srv := newBlockingLoginServer(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := NewClientContext(ctx, Options{RemoteAddr: srv.URL, Username: "user", Password: "password"})
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("expected context.Canceled, got: %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("NewClientContext did not return after ctx has been canceled")
+	}
+}
+
+func TestNewClientContextCanceledAfterLogin(t *testing.T) {
+	var logins atomic.Int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == defaultLoginPath {
+			logins.Add(1)
+			writeLoginResponse(w)
+			return
+		}
+		writeJSON(w, GetBoard{Title: "board"})
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	c, err := NewClientContext(ctx, Options{RemoteAddr: srv.URL, Username: "user", Password: "password"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close_()
+
+	// The ctx only governs the initial login.
+	cancel()
+
+	// Renewing the token requires the token routine to be alive.
+	reqCtx, reqCancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer reqCancel()
+
+	_, err = c.GetBoard(ForceRefresh(reqCtx), "board-id")
+	if err != nil {
+		t.Fatalf("request after canceling ctx failed: %v", err)
+	} else if c.IsClosing() {
+		t.Fatal("client is closing after canceling ctx")
+	} else if n := logins.Load(); n != 2 {
+		t.Fatalf("expected 2 logins, got %d", n)
+	}
+}
+
+// assertJSON fails the test, if v does not marshal to the JSON document want.
+// The order of object keys is ignored.
+func assertJSON(t *testing.T, v any, want string) {
+	t.Helper()
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("failed to marshal %T: %v", v, err)
+	}
+
+	var gotDoc, wantDoc any
+	if err = json.Unmarshal(data, &gotDoc); err != nil {
+		t.Fatalf("%T marshaled to invalid JSON: %v", v, err)
+	}
+	if err = json.Unmarshal([]byte(want), &wantDoc); err != nil {
+		t.Fatalf("invalid expected JSON: %v", err)
+	}
+	if !reflect.DeepEqual(gotDoc, wantDoc) {
+		t.Fatalf("%T marshaled to %s, expected %s", v, data, want)
+	}
+}
@@ -10,6 +10,8 @@ package wego
 import (
 	"context"
 	"encoding/json"
+	"sort"
+	"time"
 )
 
 // GetCurrentUserID returns the id of the logged in user.
@@ -142,6 +144,24 @@ func (c *Client) GetUser(ctx context.Context, userID string) (user User, err err
 	return
 }
 
+// GetUserNotifications returns the notifications of the user, which are stored in the
+// user's profile.
+// It performs a single get_user request and reads the notifications from the profile.
+// Notifications can not be marked as read via the API.
+// The profile does not store when a notification has been created, this time is only
+// available as Activity.CreatedAt of the activity referenced by ProfileNotification.ActivityID,
+// e.g. from GetBoardActivities.
+//
+// Returns ErrNotFound, if the user could not be found.
+func (c *Client) GetUserNotifications(ctx context.Context, userID string) (notifications []ProfileNotification, err error) {
+	user, err := c.GetUser(ctx, userID)
+	if err != nil {
+		return
+	}
+
+	return user.Profile.Notifications.Entries, nil
+}
+
 // EditUser performs a edit_user request against the Wekan server.
 // See https://wekan.github.io/api/v5.13/#edit_user
 //
@@ -189,7 +209,8 @@ type removeBoardMemberRequest struct {
 }
 
 type CreateUserTokenResponse struct {
-	ID string `json:"_id"`
+	ID        string `json:"_id"`
+	AuthToken string `json:"authToken"`
 }
 
 type User struct {
@@ -198,7 +219,7 @@ type User struct {
 	CreatedAt            string          `json:"createdAt"`
 	ModifiedAt           string          `json:"modifiedAt"`
 	Profile              UserProfile     `json:"profile"`
-	Services             json.RawMessage `json:"services"`
+	Services             UserServices    `json:"services"`
 	Heartbeat            string          `json:"heartbeat"`
 	IsAdmin              bool            `json:"isAdmin"`
 	CreatedThroughApi    bool            `json:"createdThroughApi"`
@@ -219,29 +240,29 @@ type UserSessionData struct {
 }
 
 type UserProfile struct {
-	AvatarUrl                string          `json:"avatarUrl"`
-	EmailBuffer              []string        `json:"emailBuffer"`
-	Fullname                 string          `json:"fullname"`
-	ShowDesktopDragHandles   bool            `json:"showDesktopDragHandles"`
-	HideCheckedItems         bool            `json:"hideCheckedItems"`
-	HiddenSystemMessages     bool            `json:"hiddenSystemMessages"`
-	HiddenMinicardLabelText  bool            `json:"hiddenMinicardLabelText"`
-	Initials                 string          `json:"initials"`
-	InvitedBoards            []string        `json:"invitedBoards"`
-	Language                 string          `json:"language"`
-	Notifications            json.RawMessage `json:"notifications"`
-	Activity                 string          `json:"activity"`
-	Read                     string          `json:"read"`
-	ShowCardsCountAt         int             `json:"showCardsCountAt"`
-	StartDayOfWeek           int             `json:"startDayOfWeek"`
-	StarredBoards            []string        `json:"starredBoards"`
-	Icode                    string          `json:"icode"`
-	BoardView                string          `json:"boardView"`
-	ListSortBy               string          `json:"listSortBy"`
-	TemplatesBoardID         string          `json:"templatesBoardId"`
-	CardTemplatesSwimlaneID  string          `json:"cardTemplatesSwimlaneId"`
-	ListTemplatesSwimlaneID  string          `json:"listTemplatesSwimlaneId"`
-	BoardTemplatesSwimlaneID string          `json:"boardTemplatesSwimlaneId"`
+	AvatarUrl                string               `json:"avatarUrl"`
+	EmailBuffer              []string             `json:"emailBuffer"`
+	Fullname                 string               `json:"fullname"`
+	ShowDesktopDragHandles   bool                 `json:"showDesktopDragHandles"`
+	HideCheckedItems         bool                 `json:"hideCheckedItems"`
+	HiddenSystemMessages     bool                 `json:"hiddenSystemMessages"`
+	HiddenMinicardLabelText  bool                 `json:"hiddenMinicardLabelText"`
+	Initials                 string               `json:"initials"`
+	InvitedBoards            []string             `json:"invitedBoards"`
+	Language                 string               `json:"language"`
+	Notifications            ProfileNotifications `json:"notifications"`
+	Activity                 string               `json:"activity"`
+	Read                     string               `json:"read"`
+	ShowCardsCountAt         int                  `json:"showCardsCountAt"`
+	StartDayOfWeek           int                  `json:"startDayOfWeek"`
+	StarredBoards            []string             `json:"starredBoards"`
+	Icode                    string               `json:"icode"`
+	BoardView                string               `json:"boardView"`
+	ListSortBy               string               `json:"listSortBy"`
+	TemplatesBoardID         string               `json:"templatesBoardId"`
+	CardTemplatesSwimlaneID  string               `json:"cardTemplatesSwimlaneId"`
+	ListTemplatesSwimlaneID  string               `json:"listTemplatesSwimlaneId"`
+	BoardTemplatesSwimlaneID string               `json:"boardTemplatesSwimlaneId"`
 }
 
 type GetAllUser struct {
@@ -262,3 +283,114 @@ type NewUserResponse struct {
 type editUserRequest struct {
 	Action string `json:"action"`
 }
+
+// UserServices are the authentication services of a user.
+// Unknown or malformed data is tolerated, the original JSON is always kept in Raw.
+type UserServices struct {
+	// True, if the user can log in with a password.
+	HasPassword bool
+	// The names of the other login services of the user, e.g. "oidc" or "ldap".
+	Providers []string
+	// The number of active login tokens.
+	LoginTokens int
+
+	Raw json.RawMessage
+}
+
+// Services that are not login providers.
+var userServicesNoProvider = map[string]struct{}{
+	"password": {},
+	"resume":   {},
+	"email":    {},
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *UserServices) UnmarshalJSON(data []byte) error {
+	*s = UserServices{Raw: append(json.RawMessage(nil), data...)}
+
+	var services map[string]json.RawMessage
+	if json.Unmarshal(data, &services) != nil {
+		return nil
+	}
+
+	var password struct {
+		Bcrypt string `json:"bcrypt"`
+	}
+	if json.Unmarshal(services["password"], &password) == nil {
+		s.HasPassword = password.Bcrypt != ""
+	}
+
+	var resume struct {
+		LoginTokens []json.RawMessage `json:"loginTokens"`
+	}
+	if json.Unmarshal(services["resume"], &resume) == nil {
+		s.LoginTokens = len(resume.LoginTokens)
+	}
+
+	for name := range services {
+		if _, ok := userServicesNoProvider[name]; !ok {
+			s.Providers = append(s.Providers, name)
+		}
+	}
+	sort.Strings(s.Providers)
+
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s UserServices) MarshalJSON() ([]byte, error) {
+	if len(s.Raw) == 0 {
+		return []byte("null"), nil
+	}
+	return s.Raw, nil
+}
+
+// ProfileNotifications are the notifications stored in the profile of a user.
+// Unknown or malformed data is tolerated, the original JSON is always kept in Raw.
+type ProfileNotifications struct {
+	Entries []ProfileNotification
+
+	Raw json.RawMessage
+}
+
+// ProfileNotification is a notification stored in the profile of a user.
+// It has no created time, see GetUserNotifications.
+type ProfileNotification struct {
+	// The id of the activity the notification refers to.
+	// Its Activity.CreatedAt is the time the notification has been created.
+	ActivityID string `json:"activity"`
+	// The time the notification has been read, nil if unread.
+	Read *time.Time `json:"read"`
+}
+
+// Unread reports, whether the notification has not been read yet.
+func (n ProfileNotification) Unread() bool {
+	return n.Read == nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (n *ProfileNotifications) UnmarshalJSON(data []byte) error {
+	*n = ProfileNotifications{Raw: append(json.RawMessage(nil), data...)}
+
+	var entries []json.RawMessage
+	if json.Unmarshal(data, &entries) != nil {
+		return nil
+	}
+
+	for _, e := range entries {
+		var pn ProfileNotification
+		if json.Unmarshal(e, &pn) == nil {
+			n.Entries = append(n.Entries, pn)
+		}
+	}
+
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (n ProfileNotifications) MarshalJSON() ([]byte, error) {
+	if len(n.Raw) == 0 {
+		return []byte("null"), nil
+	}
+	return n.Raw, nil
+}
@@ -0,0 +1,176 @@
+/**
+ * Copyright (c) 2023 Sebastian Borchers
+ *
+ * This software is released under the MIT License.
+ * https://opensource.org/licenses/MIT
+ */
+
+package wego
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// The maximum delay between two polls of WatchBoard, if the server keeps failing.
+	maxWatchBackoff = 5 * time.Minute
+
+	// The buffer size of the channel returned by WatchBoard.
+	watchChanSize = 32
+)
+
+// WatchBoard polls the activities of a board every interval and emits a BoardChange for
+// every new activity on the returned channel.
+// Activities that exist when the watch starts are not emitted.
+// The channel is closed, once ctx is canceled or the client is closed.
+// If a poll fails, the error is logged and the interval is doubled until a poll succeeds
+// again, up to a maximum of 5 minutes.
+//
+// Note: The changes are derived from the board's activities, see GetBoardActivities.
+// Every poll therefore performs a full board export.
+func (c *Client) WatchBoard(ctx context.Context, boardID string, interval time.Duration) (<-chan BoardChange, error) {
+	if interval <= 0 {
+		return nil, fmt.Errorf("invalid watch interval '%v': must be positive", interval)
+	}
+
+	// Establish the baseline, so that only new activities are emitted.
+	activities, err := c.GetBoardActivities(ctx, boardID, ActivitiesOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	var cur watchCursor
+	cur.advance(activities)
+
+	changes := make(chan BoardChange, watchChanSize)
+	go c.watchBoardRoutine(ctx, boardID, interval, cur, changes)
+
+	return changes, nil
+}
+
+//################//
+//### Internal ###//
+//################//
+
+func (c *Client) watchBoardRoutine(ctx context.Context, boardID string, interval time.Duration, cur watchCursor, changes chan<- BoardChange) {
+	defer close(changes)
+
+	delay := interval
+	t := time.NewTimer(delay)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-c.ClosingChan():
+			return
+		case <-t.C:
+		}
+
+		activities, err := c.GetBoardActivities(ctx, boardID, ActivitiesOptions{})
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+
+			delay *= 2
+			if delay > maxWatchBackoff {
+				delay = maxWatchBackoff
+			}
+			log.Warn().Err(err).Str("boardID", boardID).Dur("retryIn", delay).Msg("watch board: failed to poll activities")
+			t.Reset(delay)
+			continue
+		}
+		delay = interval
+
+		for _, a := range cur.advance(activities) {
+			select {
+			case changes <- newBoardChange(a):
+			case <-ctx.Done():
+				return
+			case <-c.ClosingChan():
+				return
+			}
+		}
+
+		t.Reset(delay)
+	}
+}
+
+// watchCursor tracks the activities that have already been seen by WatchBoard.
+type watchCursor struct {
+	// The creation time of the newest activity seen.
+	last time.Time
+	// The IDs of the activities seen that were created at last.
+	seen map[string]struct{}
+}
+
+// advance returns the activities that have not been seen yet, oldest first, and marks
+// them as seen. The given activities must be sorted newest first.
+func (wc *watchCursor) advance(activities []Activity) (unseen []Activity) {
+	for i := len(activities) - 1; i >= 0; i-- {
+		a := activities[i]
+		if a.CreatedAt.Before(wc.last) {
+			continue
+		} else if a.CreatedAt.Equal(wc.last) {
+			if _, ok := wc.seen[a.ID]; ok {
+				continue
+			} else if wc.seen == nil {
+				// Activities without a creation time equal the zero last of a new cursor.
+				wc.seen = make(map[string]struct{})
+			}
+		} else {
+			wc.last = a.CreatedAt
+			wc.seen = make(map[string]struct{})
+		}
+
+		wc.seen[a.ID] = struct{}{}
+		unseen = append(unseen, a)
+	}
+	return
+}
+
+//#############//
+//### Types ###//
+//#############//
+
+// BoardChange is a change of a board detected by WatchBoard.
+type BoardChange struct {
+	Kind BoardChangeKind
+	// The activity the change has been derived from.
+	Activity Activity
+}
+
+// BoardChangeKind is the kind of a BoardChange.
+type BoardChangeKind string
+
+const (
+	BoardChangeCardAdded     BoardChangeKind = "cardAdded"
+	BoardChangeCardMoved     BoardChangeKind = "cardMoved"
+	BoardChangeCardCommented BoardChangeKind = "cardCommented"
+	BoardChangeCardArchived  BoardChangeKind = "cardArchived"
+	// BoardChangeOther covers all activities that have no dedicated kind.
+	// Inspect the activity's type for details.
+	BoardChangeOther BoardChangeKind = "other"
+)
+
+func newBoardChange(a Activity) BoardChange {
+	kind := BoardChangeOther
+	switch a.ActivityType {
+	case ActivityTypeCreateCard:
+		kind = BoardChangeCardAdded
+	case ActivityTypeMoveCard, ActivityTypeMoveCardToOtherBoard:
+		kind = BoardChangeCardMoved
+	case ActivityTypeAddComment:
+		kind = BoardChangeCardCommented
+	case ActivityTypeArchivedCard:
+		kind = BoardChangeCardArchived
+	}
+
+	return BoardChange{Kind: kind, Activity: a}
+}
@@ -0,0 +1,76 @@
+/**
+ * Copyright (c) 2023 Sebastian Borchers
+ *
+ * This software is released under the MIT License.
+ * https://opensource.org/licenses/MIT
+ */
+
+package wego
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestWatchBoardActivitiesWithoutCreationTime(t *testing.T) {
+	var (
+		mx         sync.Mutex
+		activities = []map[string]any{{"_id": "a1", "activityType": "createCard"}}
+	)
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/boards/b/export" {
+			http.NotFound(w, r)
+			return
+		}
+
+		mx.Lock()
+		defer mx.Unlock()
+		writeJSON(w, map[string]any{"activities": activities})
+	})
+	c := newTestClient(t, srv, Options{})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	changes, err := c.WatchBoard(ctx, "b", 10*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mx.Lock()
+	activities = append(activities, map[string]any{"_id": "a2", "activityType": "addComment"})
+	mx.Unlock()
+
+	select {
+	case change := <-changes:
+		if change.Activity.ID != "a2" || change.Kind != BoardChangeCardCommented {
+			t.Fatalf("expected a comment change of activity 'a2', got %+v", change)
+		}
+	case <-ctx.Done():
+		t.Fatal("no change emitted")
+	}
+}
+
+func TestWatchCursorAdvance(t *testing.T) {
+	t0 := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	var cur watchCursor
+	unseen := cur.advance([]Activity{{ID: "a"}, {ID: "b"}})
+	if len(unseen) != 2 {
+		t.Fatalf("expected 2 unseen activities, got %d", len(unseen))
+	}
+
+	// Sorted newest first, like GetBoardActivities returns them.
+	unseen = cur.advance([]Activity{{ID: "c", CreatedAt: t0}, {ID: "a"}, {ID: "b"}})
+	if len(unseen) != 1 || unseen[0].ID != "c" {
+		t.Fatalf("expected only activity 'c' to be unseen, got %+v", unseen)
+	}
+
+	unseen = cur.advance([]Activity{{ID: "d", CreatedAt: t0}, {ID: "c", CreatedAt: t0}, {ID: "a"}})
+	if len(unseen) != 1 || unseen[0].ID != "d" {
+		t.Fatalf("expected only activity 'd' to be unseen, got %+v", unseen)
+	}
+}
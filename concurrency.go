@@ -0,0 +1,64 @@
+/**
+ * Copyright (c) 2023 Sebastian Borchers
+ *
+ * This software is released under the MIT License.
+ * https://opensource.org/licenses/MIT
+ */
+
+package wego
+
+import (
+	"context"
+	"sync"
+)
+
+const defaultMaxConcurrency = 8
+
+// forEachConcurrent calls fn for every index in [0, n) with at most MaxConcurrency
+// calls running at the same time.
+// Once ctx is done, no further calls are started and the remaining indexes fail with the
+// error of ctx.
+// Returns a *BatchError holding the errors of all failed indexes, or nil.
+func (c *Client) forEachConcurrent(ctx context.Context, n int, fn func(ctx context.Context, i int) error) error {
+	var (
+		wg  sync.WaitGroup
+		mx  sync.Mutex
+		sem = make(chan struct{}, c.opts.MaxConcurrency)
+
+		errs = make(map[int]error)
+	)
+
+	setErr := func(i int, err error) {
+		mx.Lock()
+		errs[i] = err
+		mx.Unlock()
+	}
+
+	for i := 0; i < n; i++ {
+		select {
+		case <-ctx.Done():
+			setErr(i, ctx.Err())
+			continue
+		case sem <- struct{}{}:
+		}
+
+		wg.Add(1)
+		go func(i int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			err := fn(ctx, i)
+			if err != nil {
+				setErr(i, err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return &BatchError{Errors: errs}
+}
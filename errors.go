@@ -7,8 +7,128 @@
 
 package wego
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
 
 var (
 	ErrNotFound = errors.New("not found")
+
+	// ErrForbidden is matched by errors of requests the server rejected with status 403,
+	// e.g. because they require admin privileges.
+	ErrForbidden = errors.New("forbidden")
+
+	// ErrConflict is matched by errors of requests the server rejected with status 409,
+	// e.g. because the resource is still referenced by others.
+	ErrConflict = errors.New("conflict")
+
+	// ErrNetwork is wrapped by all errors that are caused by a transport-level failure,
+	// i.e. the request never received a response from the server.
+	ErrNetwork = errors.New("network error")
+
+	// ErrInvalidCredentials is returned by CheckCredentials, if the server rejected the
+	// username or password.
+	ErrInvalidCredentials = errors.New("invalid credentials")
+
+	// ErrHTMLResponse is returned, if the server responds with an HTML page instead of JSON.
+	// This usually means that RemoteAddr does not point at the Wekan server, but e.g. at a
+	// login or error page of a proxy.
+	ErrHTMLResponse = errors.New("expected JSON, but received HTML")
+
+	// ErrResponseTooLarge is returned, if a response exceeds Options.MaxResponseBytes.
+	ErrResponseTooLarge = errors.New("response too large")
 )
+
+// APIError is returned, if the Wekan server responds with an unexpected HTTP status code.
+// If the status code is 404, errors.Is(err, ErrNotFound) reports true,
+// if it is 403, errors.Is(err, ErrForbidden) reports true,
+// if it is 409, errors.Is(err, ErrConflict) reports true.
+type APIError struct {
+	// The HTTP status code of the response.
+	StatusCode int
+	// The reason reported by the server, if any.
+	Reason string
+}
+
+func (e *APIError) Error() string {
+	if e.Reason == "" {
+		return fmt.Sprintf("unexpected status code '%d' received", e.StatusCode)
+	}
+	return fmt.Sprintf("unexpected status code '%d' received: %s", e.StatusCode, e.Reason)
+}
+
+// Is reports whether e matches target.
+// An APIError with status code 404 matches ErrNotFound, one with 403 matches ErrForbidden
+// and one with 409 matches ErrConflict.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden
+	case ErrConflict:
+		return e.StatusCode == http.StatusConflict
+	default:
+		return false
+	}
+}
+
+// newAPIError creates an APIError from the given response.
+// The reason is parsed from the response body on a best effort basis.
+func (c *Client) newAPIError(resp *http.Response) *APIError {
+	e := &APIError{StatusCode: resp.StatusCode}
+
+	var body apiErrorResponse
+	err := c.parseResponse(resp, &body)
+	if err == nil {
+		e.Reason = body.Reason
+		if e.Reason == "" {
+			e.Reason = body.Message
+		}
+	} else if errors.Is(err, ErrHTMLResponse) {
+		e.Reason = "received HTML instead of JSON, is RemoteAddr correct?"
+	}
+
+	return e
+}
+
+type apiErrorResponse struct {
+	Reason  string `json:"reason"`
+	Message string `json:"message"`
+}
+
+// BatchError is returned by methods that perform an operation for multiple items,
+// if the operation failed for some of them.
+type BatchError struct {
+	// The errors of the failed items, keyed by the index of the item
+	// in the slice passed to the method.
+	Errors map[int]error
+}
+
+func (e *BatchError) Error() string {
+	indexes := make([]int, 0, len(e.Errors))
+	for i := range e.Errors {
+		indexes = append(indexes, i)
+	}
+	sort.Ints(indexes)
+
+	msgs := make([]string, len(indexes))
+	for j, i := range indexes {
+		msgs[j] = fmt.Sprintf("item %d: %v", i, e.Errors[i])
+	}
+	return fmt.Sprintf("%d operations failed: %s", len(e.Errors), strings.Join(msgs, "; "))
+}
+
+// Unwrap returns the errors of all failed items, so that errors.Is and errors.As
+// match any of them.
+func (e *BatchError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		errs = append(errs, err)
+	}
+	return errs
+}
@@ -0,0 +1,105 @@
+/**
+ * Copyright (c) 2023 Sebastian Borchers
+ *
+ * This software is released under the MIT License.
+ * https://opensource.org/licenses/MIT
+ */
+
+package wego
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	idempotencyKeyHeader = "Idempotency-Key"
+
+	// How long the result of a request with an idempotency key is remembered.
+	idempotencyKeyTTL = 10 * time.Minute
+)
+
+// idempotencyCache remembers the responses of successful requests by their idempotency key,
+// so that a request repeated with the same key is not submitted to the server again.
+// Wekan does not deduplicate requests on its own.
+type idempotencyCache struct {
+	mx      sync.Mutex
+	entries map[string]idempotencyEntry
+}
+
+type idempotencyEntry struct {
+	resp    []byte
+	expires time.Time
+}
+
+func (ic *idempotencyCache) get(key string) (resp []byte, ok bool) {
+	ic.mx.Lock()
+	defer ic.mx.Unlock()
+
+	e, ok := ic.entries[key]
+	if !ok || time.Now().After(e.expires) {
+		return nil, false
+	}
+	return e.resp, true
+}
+
+func (ic *idempotencyCache) set(key string, resp []byte) {
+	ic.mx.Lock()
+	defer ic.mx.Unlock()
+
+	now := time.Now()
+	if ic.entries == nil {
+		ic.entries = make(map[string]idempotencyEntry)
+	}
+
+	// Prune expired entries.
+	for k, e := range ic.entries {
+		if now.After(e.expires) {
+			delete(ic.entries, k)
+		}
+	}
+
+	ic.entries[key] = idempotencyEntry{resp: resp, expires: now.Add(idempotencyKeyTTL)}
+}
+
+// NewIdempotencyKey returns a new random idempotency key.
+func NewIdempotencyKey() string {
+	return randomID()
+}
+
+// doIdempotentRequest behaves like doSimpleRequest, but sends the given idempotency key
+// as header and does not send req at all, if a request with the same key succeeded recently.
+// In that case, the remembered response is loaded into resp.
+// If key is empty, a new one is generated for the header, but the response is not
+// remembered, since the generated key can not be repeated by the caller.
+// The argument resp must be a pointer.
+func (c *Client) doIdempotentRequest(req *http.Request, key string, resp any) error {
+	remember := key != ""
+	if !remember {
+		key = NewIdempotencyKey()
+	} else if data, ok := c.idempotency.get(key); ok {
+		err := json.Unmarshal(data, resp)
+		if err != nil {
+			return fmt.Errorf("failed to unmarshal remembered response: %v", err)
+		}
+		return nil
+	}
+
+	req.Header.Set(idempotencyKeyHeader, key)
+
+	err := c.doSimpleRequest(req, resp)
+	if err != nil || !remember {
+		return err
+	}
+
+	data, err := json.Marshal(resp)
+	if err != nil {
+		return fmt.Errorf("failed to marshal response: %v", err)
+	}
+	c.idempotency.set(key, data)
+
+	return nil
+}
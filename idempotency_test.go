@@ -0,0 +1,74 @@
+/**
+ * Copyright (c) 2023 Sebastian Borchers
+ *
+ * This software is released under the MIT License.
+ * https://opensource.org/licenses/MIT
+ */
+
+package wego
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+)
+
+func TestNewCardIdempotencyKey(t *testing.T) {
+	var (
+		mx   sync.Mutex
+		keys []string
+	)
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/boards/b/lists/l/cards" {
+			http.NotFound(w, r)
+			return
+		}
+
+		mx.Lock()
+		defer mx.Unlock()
+		keys = append(keys, r.Header.Get(idempotencyKeyHeader))
+		writeJSON(w, NewCardResponse{ID: fmt.Sprintf("card-%d", len(keys))})
+	})
+	c := newTestClient(t, srv, Options{})
+	ctx := context.Background()
+
+	// Without a key, every card is submitted with a generated key, that is not remembered.
+	for i := 0; i < 3; i++ {
+		_, err := c.NewCard(ctx, "b", "l", NewCardRequest{Title: "card"})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n := len(c.idempotency.entries); n != 0 {
+		t.Fatalf("expected no remembered responses, got %d", n)
+	}
+
+	// With a key, the card is submitted once.
+	for i := 0; i < 2; i++ {
+		r, err := c.NewCard(ctx, "b", "l", NewCardRequest{Title: "card", NewCardOptions: NewCardOptions{IdempotencyKey: "key"}})
+		if err != nil {
+			t.Fatal(err)
+		} else if r.ID != "card-4" {
+			t.Fatalf("expected the card 'card-4', got '%s'", r.ID)
+		}
+	}
+
+	mx.Lock()
+	defer mx.Unlock()
+
+	if len(keys) != 4 {
+		t.Fatalf("expected 4 submitted cards, got %d", len(keys))
+	}
+	seen := make(map[string]struct{})
+	for _, k := range keys[:3] {
+		if _, ok := seen[k]; ok || k == "" {
+			t.Fatalf("expected unique generated keys, got %q", keys)
+		}
+		seen[k] = struct{}{}
+	}
+	if keys[3] != "key" {
+		t.Fatalf("expected the key 'key', got '%s'", keys[3])
+	}
+}
@@ -0,0 +1,14 @@
+/**
+ * Copyright (c) 2023 Sebastian Borchers
+ *
+ * This software is released under the MIT License.
+ * https://opensource.org/licenses/MIT
+ */
+
+package wego
+
+// Ptr returns a pointer to v.
+// It is useful to set the optional fields of the Edit* request types inline.
+func Ptr[T any](v T) *T {
+	return &v
+}
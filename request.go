@@ -11,12 +11,20 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"reflect"
 	"strings"
+
+	"github.com/rs/zerolog/log"
 )
 
+// The maximum number of bytes that are drained from a response body before closing it.
+const maxDrainBytes = 64 << 10
+
 func (c *Client) newAuthenticatedGETRequest(ctx context.Context, endpoint string) (req *http.Request, err error) {
 	req, err = c.newGETRequest(ctx, endpoint)
 	if err != nil {
@@ -36,15 +44,16 @@ func (c *Client) newGETRequest(ctx context.Context, endpoint string) (req *http.
 
 	// Set headers.
 	req.Header.Set("Accept", "application/json")
+	c.setCommonHeaders(ctx, req)
 
 	return
 }
 
 func (c *Client) newAuthenticatedPOSTRequest(ctx context.Context, endpoint string, body any) (req *http.Request, err error) {
 	// Marshal the request data to JSON.
-	reqData, err := json.Marshal(body)
+	reqData, err := marshalBody(http.MethodPost, body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal json: %v", err)
+		return nil, err
 	}
 
 	req, err = http.NewRequestWithContext(ctx, http.MethodPost, c.opts.RemoteAddr+endpoint, bytes.NewReader(reqData))
@@ -55,6 +64,7 @@ func (c *Client) newAuthenticatedPOSTRequest(ctx context.Context, endpoint strin
 	// Set headers.
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept", "application/json")
+	c.setCommonHeaders(ctx, req)
 	c.authenticateRequest(ctx, req)
 
 	return
@@ -62,9 +72,9 @@ func (c *Client) newAuthenticatedPOSTRequest(ctx context.Context, endpoint strin
 
 func (c *Client) newAuthenticatedPUTRequest(ctx context.Context, endpoint string, body any) (req *http.Request, err error) {
 	// Marshal the request data to JSON.
-	reqData, err := json.Marshal(body)
+	reqData, err := marshalBody(http.MethodPut, body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal json: %v", err)
+		return nil, err
 	}
 
 	req, err = http.NewRequestWithContext(ctx, http.MethodPut, c.opts.RemoteAddr+endpoint, strings.NewReader(string(reqData)))
@@ -75,6 +85,7 @@ func (c *Client) newAuthenticatedPUTRequest(ctx context.Context, endpoint string
 	// Set headers.
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept", "application/json")
+	c.setCommonHeaders(ctx, req)
 	c.authenticateRequest(ctx, req)
 
 	return
@@ -87,21 +98,86 @@ func (c *Client) newAuthenticatedDELETERequest(ctx context.Context, endpoint str
 	}
 
 	// Set headers.
+	c.setCommonHeaders(ctx, req)
 	c.authenticateRequest(ctx, req)
 
 	return
 }
 
+// marshalBody marshals the body of a request with the given method to JSON.
+// The returned error names the method and the type of the body.
+func marshalBody(method string, body any) ([]byte, error) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("%s request: failed to marshal json body of type %T: %w", method, body, err)
+	}
+
+	return data, nil
+}
+
+// setCommonHeaders sets the headers on req that are shared by all requests of the client.
+func (c *Client) setCommonHeaders(ctx context.Context, req *http.Request) {
+	if c.opts.Language != "" {
+		req.Header.Set("Accept-Language", c.opts.Language)
+	}
+	if c.opts.DisableCompression {
+		// The explicit header also covers the transports of custom clients.
+		req.Header.Set("Accept-Encoding", "identity")
+	}
+
+	c.setRequestID(ctx, req)
+}
+
+// exists is a helper that performs an authenticated GET request on the given endpoint
+// and reports, whether the server returned a resource.
+// The response is not unmarshaled.
+// Wekan's routes do not answer HEAD requests, therefore a GET request is used.
+func (c *Client) exists(ctx context.Context, endpoint string) (bool, error) {
+	req, err := c.newAuthenticatedGETRequest(ctx, endpoint)
+	if err != nil {
+		return false, err
+	}
+
+	var raw json.RawMessage
+	err = c.doSimpleRequest(req, &raw)
+	if err != nil {
+		if errors.Is(err, io.EOF) || errors.Is(err, ErrNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return string(raw) != "null", nil
+}
+
 // doSimpleRequest is a helper that executes the given request and attempts to parse
 // its JSON response into resp.
 // The argument resp must be a pointer.
-// If any other status code than 200 is received, an error is returned.
+// If any other status code than 200 is received, an *APIError is returned.
+// A 404 status code results in an error that matches ErrNotFound.
+// Transport-level failures are wrapped with ErrNetwork.
+// The response body is drained and closed on all paths, so that the connection can be reused.
 func (c *Client) doSimpleRequest(req *http.Request, resp any) error {
-	r, err := c.httpc.Do(req)
+	return c.withRequestID(req, c.doRequest(req, resp))
+}
+
+// doRequest implements doSimpleRequest.
+func (c *Client) doRequest(req *http.Request, resp any) error {
+	if c.skipDryRun(req) {
+		return nil
+	}
+
+	req, cancel := c.withTimeout(req)
+	defer cancel()
+
+	r, err := c.send(req)
 	if err != nil {
-		return fmt.Errorf("failed to send POST request: %v", err)
-	} else if r.StatusCode != http.StatusOK {
-		return fmt.Errorf("unexpected status code '%d' received", r.StatusCode)
+		return sendError(req, err)
+	}
+	defer closeBody(r.Body)
+
+	if r.StatusCode != http.StatusOK {
+		return c.newAPIError(r)
 	}
 
 	// If no return value is expected, do not parse the response.
@@ -110,7 +186,7 @@ func (c *Client) doSimpleRequest(req *http.Request, resp any) error {
 	}
 
 	// Parse response.
-	err = parseResponse(r, &resp)
+	err = c.parseResponse(r, resp)
 	if err != nil {
 		return fmt.Errorf("failed to parse response: %w", err)
 	}
@@ -118,15 +194,119 @@ func (c *Client) doSimpleRequest(req *http.Request, resp any) error {
 	return nil
 }
 
+// doStreamRequest is a helper that executes the given request and passes its response
+// body to fn, without buffering it. The body is closed afterwards.
+// Errors are reported the same way as by doSimpleRequest. Since the body is not buffered,
+// Options.MaxResponseBytes does not apply and HTML pages are only detected by their
+// content type.
+func (c *Client) doStreamRequest(req *http.Request, fn func(body io.Reader) error) error {
+	req, cancel := c.withTimeout(req)
+	defer cancel()
+
+	r, err := c.send(req)
+	if err != nil {
+		return c.withRequestID(req, sendError(req, err))
+	}
+	defer closeBody(r.Body)
+
+	if r.StatusCode != http.StatusOK {
+		return c.withRequestID(req, c.newAPIError(r))
+	} else if isHTML(r, nil) {
+		return c.withRequestID(req, htmlResponseError(r))
+	}
+
+	return c.withRequestID(req, fn(r.Body))
+}
+
+// decodeJSONArray decodes the JSON array read from r element by element into values
+// of type T and passes each one to fn. Decoding stops early, if fn returns false.
+func decodeJSONArray[T any](r io.Reader, fn func(T) bool) error {
+	return decodeNextJSONArray(json.NewDecoder(r), fn)
+}
+
+// decodeNextJSONArray is like decodeJSONArray, but decodes the next value of dec.
+// Unless decoding stopped early, the closing bracket of the array is consumed as well.
+func decodeNextJSONArray[T any](dec *json.Decoder, fn func(T) bool) error {
+	t, err := dec.Token()
+	if err != nil {
+		return fmt.Errorf("failed to decode response: %v", err)
+	} else if d, ok := t.(json.Delim); !ok || d != '[' {
+		return fmt.Errorf("failed to decode response: expected JSON array, got '%v'", t)
+	}
+
+	for dec.More() {
+		var v T
+		err = dec.Decode(&v)
+		if err != nil {
+			return fmt.Errorf("failed to decode response: %v", err)
+		}
+
+		if !fn(v) {
+			return nil
+		}
+	}
+
+	_, err = dec.Token()
+	if err != nil {
+		return fmt.Errorf("failed to decode response: %v", err)
+	}
+	return nil
+}
+
+// closeBody drains the remainder of a response body and closes it, so that the transport
+// can reuse the connection. Bodies with more than maxDrainBytes left are closed without
+// draining them, since reading them would take longer than opening a new connection.
+func closeBody(body io.ReadCloser) {
+	io.Copy(io.Discard, io.LimitReader(body, maxDrainBytes))
+	body.Close()
+}
+
+// skipDryRun reports, whether req must not be sent, because the client is in dry run mode
+// and req is mutating. In this case, the request is logged instead.
+func (c *Client) skipDryRun(req *http.Request) bool {
+	if !c.opts.DryRun || req.Method == http.MethodGet {
+		return false
+	}
+
+	log.Info().Str("method", req.Method).Str("path", req.URL.Path).Msg("dry run: request not sent")
+	return true
+}
+
+// sendError wraps the error err returned by sending req.
+// Unless the request's context is done, the error is marked as ErrNetwork.
+func sendError(req *http.Request, err error) error {
+	// The url of the request may hold the API token.
+	var uerr *url.Error
+	if errors.As(err, &uerr) {
+		uerr.URL = redactURL(req.URL)
+	}
+
+	if ctxErr := req.Context().Err(); ctxErr != nil {
+		return fmt.Errorf("failed to send %s request: %w", req.Method, ctxErr)
+	}
+	return fmt.Errorf("%w: failed to send %s request: %w", ErrNetwork, req.Method, err)
+}
+
 // Returns io.EOF, if the response was empty, but dst is not nil.
-func parseResponse(resp *http.Response, dst any) error {
-	data, err := io.ReadAll(resp.Body)
+// Returns ErrHTMLResponse, if the server responded with an HTML page.
+// Returns ErrResponseTooLarge, if the response exceeds the configured MaxResponseBytes.
+func (c *Client) parseResponse(resp *http.Response, dst any) error {
+	data, err := c.readResponse(resp.Body)
 	if err != nil {
-		return fmt.Errorf("failed to read response: %v", err)
+		return err
 	} else if len(data) == 0 && dst != nil {
 		return io.EOF
 	}
 
+	if isHTML(resp, data) {
+		return htmlResponseError(resp)
+	}
+
+	data, err = normalizeJSONShape(data, dst)
+	if err != nil {
+		return err
+	}
+
 	err = json.Unmarshal(data, dst)
 	if err != nil {
 		return fmt.Errorf("failed to unmarshal response: %v; raw response: %s", err, string(data))
@@ -134,3 +314,95 @@ func parseResponse(resp *http.Response, dst any) error {
 
 	return nil
 }
+
+// readResponse reads the whole response body.
+// Returns ErrResponseTooLarge, if it exceeds the configured MaxResponseBytes.
+func (c *Client) readResponse(body io.Reader) (data []byte, err error) {
+	if c.opts.MaxResponseBytes > 0 {
+		// Read one byte more than allowed to detect oversized responses.
+		body = io.LimitReader(body, c.opts.MaxResponseBytes+1)
+	}
+
+	data, err = io.ReadAll(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %v", err)
+	} else if c.opts.MaxResponseBytes > 0 && int64(len(data)) > c.opts.MaxResponseBytes {
+		return nil, fmt.Errorf("%w: limit is %d bytes", ErrResponseTooLarge, c.opts.MaxResponseBytes)
+	}
+	return
+}
+
+// htmlResponseError returns the ErrHTMLResponse error for resp.
+func htmlResponseError(resp *http.Response) error {
+	return fmt.Errorf("%w: received content type '%s' with status code '%d', is RemoteAddr correct?",
+		ErrHTMLResponse, resp.Header.Get("Content-Type"), resp.StatusCode)
+}
+
+// isHTML reports, whether the response with the given body is an HTML page instead of JSON,
+// as served by e.g. login pages or proxy error pages.
+func isHTML(resp *http.Response, data []byte) bool {
+	if strings.HasPrefix(resp.Header.Get("Content-Type"), "text/html") {
+		return true
+	}
+
+	data = bytes.TrimSpace(data)
+	return len(data) > 0 && data[0] == '<'
+}
+
+// normalizeJSONShape adapts data to the shape expected by dst, since some Wekan versions
+// respond with a single object where others respond with a one-element array:
+// A single object with an id is wrapped into an array, if dst points to a slice. Other
+// objects, like {} or error objects sent with status 200, are rejected.
+// A one-element array is unwrapped, if dst points to a struct.
+// Returns io.EOF, if dst points to a struct, but data is an empty array.
+func normalizeJSONShape(data []byte, dst any) ([]byte, error) {
+	t := reflect.TypeOf(dst)
+	if t == nil || t.Kind() != reflect.Pointer {
+		return data, nil
+	}
+
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 {
+		return data, nil
+	}
+
+	switch t.Elem().Kind() {
+	case reflect.Slice:
+		// json.RawMessage and other byte slices accept any JSON.
+		if t.Elem().Elem().Kind() == reflect.Uint8 || trimmed[0] != '{' {
+			return data, nil
+		}
+
+		var obj struct {
+			ID string `json:"_id"`
+		}
+		err := json.Unmarshal(trimmed, &obj)
+		if err != nil || obj.ID == "" {
+			return nil, fmt.Errorf("expected a JSON array, but got an object without id; raw response: %s", string(data))
+		}
+		return append(append([]byte{'['}, trimmed...), ']'), nil
+
+	case reflect.Struct:
+		if trimmed[0] != '[' {
+			return data, nil
+		}
+
+		var elems []json.RawMessage
+		err := json.Unmarshal(trimmed, &elems)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal response: %v; raw response: %s", err, string(data))
+		}
+
+		switch len(elems) {
+		case 0:
+			return nil, io.EOF
+		case 1:
+			return elems[0], nil
+		default:
+			return nil, fmt.Errorf("expected a single object, but got an array of %d elements", len(elems))
+		}
+
+	default:
+		return data, nil
+	}
+}
@@ -0,0 +1,83 @@
+/**
+ * Copyright (c) 2023 Sebastian Borchers
+ *
+ * This software is released under the MIT License.
+ * https://opensource.org/licenses/MIT
+ */
+
+package wego
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+)
+
+type requestIDContextKey struct{}
+
+// ContextWithRequestID returns a copy of ctx that carries the given request ID.
+// The client sends it with every request made with the returned context
+// and includes it in the returned errors.
+func ContextWithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDContextKey{}, id)
+}
+
+// RequestIDFromContext returns the request ID stored in ctx by ContextWithRequestID.
+func RequestIDFromContext(ctx context.Context) (id string, ok bool) {
+	id, ok = ctx.Value(requestIDContextKey{}).(string)
+	ok = ok && id != ""
+	return
+}
+
+// requestID returns the request ID for a request made with ctx.
+// IDs set via ContextWithRequestID take precedence over the ones stored under
+// the configured RequestIDContextKey. If neither is present and GenerateRequestIDs
+// is set, a random ID is returned.
+func (c *Client) requestID(ctx context.Context) string {
+	if id, ok := RequestIDFromContext(ctx); ok {
+		return id
+	}
+
+	if c.opts.RequestIDContextKey != nil {
+		if id, ok := ctx.Value(c.opts.RequestIDContextKey).(string); ok && id != "" {
+			return id
+		}
+	}
+
+	if c.opts.GenerateRequestIDs {
+		return randomID()
+	}
+	return ""
+}
+
+// setRequestID sets the request ID header on req, if a request ID is available for ctx.
+func (c *Client) setRequestID(ctx context.Context, req *http.Request) {
+	id := c.requestID(ctx)
+	if id == "" {
+		return
+	}
+
+	req.Header.Set(c.opts.RequestIDHeader, id)
+}
+
+// withRequestID adds the request ID of req to err, if one has been sent.
+func (c *Client) withRequestID(req *http.Request, err error) error {
+	id := req.Header.Get(c.opts.RequestIDHeader)
+	if err == nil || id == "" {
+		return err
+	}
+	return fmt.Errorf("request '%s': %w", id, err)
+}
+
+// randomID returns a random hex encoded 128-bit ID.
+func randomID() string {
+	b := make([]byte, 16)
+	_, err := rand.Read(b)
+	if err != nil {
+		// Only fails, if the os is unable to provide randomness.
+		panic(fmt.Errorf("failed to read random bytes: %v", err))
+	}
+	return hex.EncodeToString(b)
+}
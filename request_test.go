@@ -0,0 +1,200 @@
+/**
+ * Copyright (c) 2023 Sebastian Borchers
+ *
+ * This software is released under the MIT License.
+ * https://opensource.org/licenses/MIT
+ */
+
+package wego
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestDoRequestClosesBodyOnErrorStatus(t *testing.T) {
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		code, _ := strconv.Atoi(strings.TrimPrefix(r.URL.Path, "/api/status/"))
+		writeErrorStatus(w, code)
+	})
+
+	tr := &trackingTransport{}
+	c := newTestClient(t, srv, Options{Client: &http.Client{Transport: tr}})
+
+	for _, code := range errorStatuses {
+		req, err := c.newAuthenticatedGETRequest(context.Background(), c.endpoint("status", strconv.Itoa(code)))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = c.doSimpleRequest(req, &struct{}{})
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) || apiErr.StatusCode != code {
+			t.Fatalf("status %d: expected an *APIError with the status code, got: %v", code, err)
+		}
+	}
+
+	if n := tr.unclosed(); n != 0 {
+		t.Fatalf("%d response bodies have not been closed", n)
+	}
+}
+
+func TestLoginClosesBodyOnErrorStatus(t *testing.T) {
+	for _, code := range errorStatuses {
+		code := code
+		t.Run(strconv.Itoa(code), func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				writeErrorStatus(w, code)
+			}))
+			defer srv.Close()
+
+			tr := &trackingTransport{}
+			err := CheckCredentialsWithClient(context.Background(), &http.Client{Transport: tr}, srv.URL, "user", "password")
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) || apiErr.StatusCode != code {
+				t.Fatalf("expected an *APIError with the status code, got: %v", err)
+			}
+
+			if n := tr.unclosed(); n != 0 {
+				t.Fatalf("%d response bodies have not been closed", n)
+			}
+		})
+	}
+}
+
+func TestParseResponseNormalizesJSONShape(t *testing.T) {
+	type item struct {
+		ID string `json:"_id"`
+	}
+
+	tests := []struct {
+		name    string
+		data    string
+		dst     func() any
+		want    any
+		wantErr error
+	}{
+		{
+			name: "object into slice",
+			data: `{"_id":"a"}`,
+			dst:  func() any { return &[]item{} },
+			want: &[]item{{ID: "a"}},
+		},
+		{
+			name:    "empty object into slice",
+			data:    `{}`,
+			dst:     func() any { return &[]item{} },
+			wantErr: errAny,
+		},
+		{
+			name:    "error object into slice",
+			data:    `{"error":"Forbidden","reason":"not allowed"}`,
+			dst:     func() any { return &[]item{} },
+			wantErr: errAny,
+		},
+		{
+			name:    "object with mismatching id into slice",
+			data:    `{"_id":1}`,
+			dst:     func() any { return &[]item{} },
+			wantErr: errAny,
+		},
+		{
+			name: "array into slice",
+			data: `[{"_id":"a"},{"_id":"b"}]`,
+			dst:  func() any { return &[]item{} },
+			want: &[]item{{ID: "a"}, {ID: "b"}},
+		},
+		{
+			name: "one-element array into struct",
+			data: ` [{"_id":"a"}] `,
+			dst:  func() any { return &item{} },
+			want: &item{ID: "a"},
+		},
+		{
+			name: "object into struct",
+			data: `{"_id":"a"}`,
+			dst:  func() any { return &item{} },
+			want: &item{ID: "a"},
+		},
+		{
+			name:    "empty array into struct",
+			data:    `[]`,
+			dst:     func() any { return &item{} },
+			wantErr: io.EOF,
+		},
+		{
+			name:    "multi-element array into struct",
+			data:    `[{"_id":"a"},{"_id":"b"}]`,
+			dst:     func() any { return &item{} },
+			wantErr: errAny,
+		},
+		{
+			name: "null into slice",
+			data: `null`,
+			dst:  func() any { return &[]item{} },
+			want: new([]item),
+		},
+		{
+			name: "null into struct",
+			data: `null`,
+			dst:  func() any { return &item{} },
+			want: &item{},
+		},
+		{
+			name: "object into raw message",
+			data: `{"_id":"a"}`,
+			dst:  func() any { return &json.RawMessage{} },
+			want: rawMessage(`{"_id":"a"}`),
+		},
+		{
+			name: "array into raw message",
+			data: `[{"_id":"a"}]`,
+			dst:  func() any { return &json.RawMessage{} },
+			want: rawMessage(`[{"_id":"a"}]`),
+		},
+	}
+
+	c := &Client{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Content-Type": {mimeJSON}},
+				Body:       io.NopCloser(strings.NewReader(tt.data)),
+			}
+
+			dst := tt.dst()
+			err := c.parseResponse(resp, dst)
+			switch {
+			case tt.wantErr == errAny:
+				if err == nil {
+					t.Fatalf("expected an error, got result %+v", dst)
+				}
+			case tt.wantErr != nil:
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("expected error %v, got: %v", tt.wantErr, err)
+				}
+			case err != nil:
+				t.Fatalf("unexpected error: %v", err)
+			case !reflect.DeepEqual(dst, tt.want):
+				t.Fatalf("expected %+v, got %+v", tt.want, dst)
+			}
+		})
+	}
+}
+
+// errAny is a sentinel for tests that expect any error.
+var errAny = errors.New("any error")
+
+func rawMessage(s string) *json.RawMessage {
+	m := json.RawMessage(s)
+	return &m
+}
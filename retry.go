@@ -0,0 +1,143 @@
+/**
+ * Copyright (c) 2023 Sebastian Borchers
+ *
+ * This software is released under the MIT License.
+ * https://opensource.org/licenses/MIT
+ */
+
+package wego
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptrace"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultMaxRetryAfter = time.Minute
+
+	// The delay before the first retry. It doubles with every further retry.
+	retryBaseDelay = 500 * time.Millisecond
+)
+
+// send sends req and retries it up to MaxRetries times, if it failed temporarily.
+// Returns the response or error of the last attempt.
+//
+// Throttled requests (429) are retried after the delay requested by the server's
+// Retry-After header, capped at MaxRetryAfter. Network failures and server errors (5xx)
+// are retried with exponential backoff, unless the request is a POST, which is not
+// idempotent.
+func (c *Client) send(req *http.Request) (r *http.Response, err error) {
+	c.stats.requests.Add(1)
+	c.stats.inFlight.Add(1)
+	defer func() {
+		c.stats.inFlight.Add(-1)
+		if err != nil || r.StatusCode != http.StatusOK {
+			c.stats.errors.Add(1)
+		}
+	}()
+
+	// Count whether the connections are reused, see ClientStats.
+	req = req.WithContext(httptrace.WithClientTrace(req.Context(), &httptrace.ClientTrace{
+		GotConn: func(info httptrace.GotConnInfo) {
+			if info.Reused {
+				c.stats.connsReused.Add(1)
+			} else {
+				c.stats.connsCreated.Add(1)
+			}
+		},
+	}))
+
+	for attempt := 0; ; attempt++ {
+		r, err = c.httpc.Do(req)
+		if attempt >= c.opts.MaxRetries {
+			return r, err
+		}
+
+		delay, ok := c.retryDelay(req, r, err, attempt)
+		if !ok {
+			return r, err
+		}
+		c.stats.retries.Add(1)
+
+		// Discard the response of the failed attempt.
+		if r != nil {
+			closeBody(r.Body)
+		}
+
+		// Rewind the request body.
+		if req.GetBody != nil {
+			req.Body, err = req.GetBody()
+			if err != nil {
+				return nil, fmt.Errorf("failed to rewind request body: %v", err)
+			}
+		}
+
+		t := time.NewTimer(delay)
+		select {
+		case <-req.Context().Done():
+			t.Stop()
+			return nil, req.Context().Err()
+		case <-t.C:
+		}
+	}
+}
+
+// retryDelay reports, whether the attempt of req with the given result should be retried,
+// and how long to wait before doing so.
+func (c *Client) retryDelay(req *http.Request, r *http.Response, err error, attempt int) (time.Duration, bool) {
+	backoff := retryBaseDelay << attempt
+
+	switch {
+	case err != nil:
+		// Do not retry, if the caller gave up.
+		if req.Context().Err() != nil || req.Method == http.MethodPost {
+			return 0, false
+		}
+		return backoff, true
+
+	case r.StatusCode == http.StatusTooManyRequests:
+		// The server did not process the request, so even a POST can be retried.
+		if d, ok := parseRetryAfter(r.Header.Get("Retry-After")); ok {
+			if d > c.opts.MaxRetryAfter {
+				d = c.opts.MaxRetryAfter
+			}
+			return d, true
+		}
+		return backoff, true
+
+	case r.StatusCode >= http.StatusInternalServerError:
+		return backoff, req.Method != http.MethodPost
+
+	default:
+		return 0, false
+	}
+}
+
+// parseRetryAfter parses the value of a Retry-After header, which is either
+// a number of seconds or an HTTP date.
+func parseRetryAfter(v string) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+
+	if secs, err := strconv.Atoi(v); err == nil {
+		if secs < 0 {
+			return 0, false
+		}
+		return time.Duration(secs) * time.Second, true
+	}
+
+	t, err := http.ParseTime(v)
+	if err != nil {
+		return 0, false
+	}
+
+	d := time.Until(t)
+	if d < 0 {
+		d = 0
+	}
+	return d, true
+}
@@ -0,0 +1,143 @@
+/**
+ * Copyright (c) 2023 Sebastian Borchers
+ *
+ * This software is released under the MIT License.
+ * https://opensource.org/licenses/MIT
+ */
+
+package wego
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestParseRetryAfter(t *testing.T) {
+	tests := []struct {
+		name   string
+		value  string
+		min    time.Duration
+		max    time.Duration
+		wantOK bool
+	}{
+		{name: "empty"},
+		{name: "seconds", value: "120", min: 2 * time.Minute, max: 2 * time.Minute, wantOK: true},
+		{name: "zero seconds", value: "0", wantOK: true},
+		{name: "negative seconds", value: "-1"},
+		{name: "fractional seconds", value: "1.5"},
+		{
+			name:   "http date",
+			value:  time.Now().Add(time.Minute).UTC().Format(http.TimeFormat),
+			min:    58 * time.Second,
+			max:    time.Minute,
+			wantOK: true,
+		},
+		{name: "http date in the past", value: "Mon, 02 Jan 2006 15:04:05 GMT", wantOK: true},
+		{name: "invalid", value: "soon"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, ok := parseRetryAfter(tt.value)
+			if ok != tt.wantOK {
+				t.Fatalf("expected ok %v, got %v", tt.wantOK, ok)
+			} else if d < tt.min || d > tt.max {
+				t.Fatalf("expected a delay between %v and %v, got %v", tt.min, tt.max, d)
+			}
+		})
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	c := &Client{opts: Options{MaxRetryAfter: 10 * time.Second}}
+
+	newResponse := func(code int, retryAfter string) *http.Response {
+		r := &http.Response{StatusCode: code, Header: http.Header{}}
+		if retryAfter != "" {
+			r.Header.Set("Retry-After", retryAfter)
+		}
+		return r
+	}
+
+	tests := []struct {
+		name    string
+		method  string
+		resp    *http.Response
+		err     error
+		attempt int
+		want    time.Duration
+		wantOK  bool
+	}{
+		{name: "throttled", method: http.MethodGet, resp: newResponse(429, "3"), want: 3 * time.Second, wantOK: true},
+		{name: "throttled capped", method: http.MethodGet, resp: newResponse(429, "3600"), want: 10 * time.Second, wantOK: true},
+		{name: "throttled without header", method: http.MethodGet, resp: newResponse(429, ""), attempt: 1, want: 2 * retryBaseDelay, wantOK: true},
+		{name: "throttled post", method: http.MethodPost, resp: newResponse(429, "1"), want: time.Second, wantOK: true},
+		{name: "server error", method: http.MethodGet, resp: newResponse(503, ""), attempt: 2, want: 4 * retryBaseDelay, wantOK: true},
+		{name: "server error post", method: http.MethodPost, resp: newResponse(500, "")},
+		{name: "client error", method: http.MethodGet, resp: newResponse(404, "")},
+		{name: "network error", method: http.MethodGet, err: errAny, want: retryBaseDelay, wantOK: true},
+		{name: "network error post", method: http.MethodPost, err: errAny},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest(tt.method, "http://localhost", nil)
+			d, ok := c.retryDelay(req, tt.resp, tt.err, tt.attempt)
+			if ok != tt.wantOK || (ok && d != tt.want) {
+				t.Fatalf("expected (%v, %v), got (%v, %v)", tt.want, tt.wantOK, d, ok)
+			}
+		})
+	}
+}
+
+func TestSendRetries(t *testing.T) {
+	tests := []struct {
+		name         string
+		method       string
+		code         int
+		wantAttempts int64
+	}{
+		{name: "get server error", method: http.MethodGet, code: http.StatusServiceUnavailable, wantAttempts: 2},
+		{name: "post server error", method: http.MethodPost, code: http.StatusInternalServerError, wantAttempts: 1},
+		{name: "post throttled", method: http.MethodPost, code: http.StatusTooManyRequests, wantAttempts: 2},
+		{name: "get not found", method: http.MethodGet, code: http.StatusNotFound, wantAttempts: 1},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts atomic.Int64
+			srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+				attempts.Add(1)
+				w.Header().Set("Retry-After", "0")
+				writeErrorStatus(w, tt.code)
+			})
+			c := newTestClient(t, srv, Options{MaxRetries: 1})
+
+			var (
+				req *http.Request
+				err error
+			)
+			if tt.method == http.MethodPost {
+				req, err = c.newAuthenticatedPOSTRequest(context.Background(), c.endpoint("x"), struct{}{})
+			} else {
+				req, err = c.newAuthenticatedGETRequest(context.Background(), c.endpoint("x"))
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = c.doSimpleRequest(req, nil)
+			if err == nil {
+				t.Fatal("expected an error")
+			} else if n := attempts.Load(); n != tt.wantAttempts {
+				t.Fatalf("expected %d attempts, got %d", tt.wantAttempts, n)
+			} else if s := c.Stats(); s.Retries != tt.wantAttempts-1 {
+				t.Fatalf("expected %d retries, got %d", tt.wantAttempts-1, s.Retries)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+/**
+ * Copyright (c) 2023 Sebastian Borchers
+ *
+ * This software is released under the MIT License.
+ * https://opensource.org/licenses/MIT
+ */
+
+package wego
+
+import "sync/atomic"
+
+// ClientStats is a snapshot of the runtime counters of a client.
+type ClientStats struct {
+	// The number of requests sent, excluding retries.
+	Requests int64
+	// The number of requests that failed with a network error or an unexpected status code,
+	// after all retries.
+	Errors int64
+	// The number of retries of failed requests.
+	Retries int64
+	// The number of successful logins, including the initial one.
+	TokenRenewals int64
+	// The number of requests currently in flight.
+	InFlight int64
+	// The number of connections opened to the server, and the number of requests that
+	// reused an idle connection instead. If ConnsCreated grows with Requests, the
+	// connections are not kept alive, e.g. because the transport's idle pool is too small,
+	// see Options.MaxIdleConnsPerHost.
+	ConnsCreated int64
+	ConnsReused  int64
+}
+
+// clientStats holds the counters of a client. They are updated without locking.
+type clientStats struct {
+	requests      atomic.Int64
+	errors        atomic.Int64
+	retries       atomic.Int64
+	tokenRenewals atomic.Int64
+	inFlight      atomic.Int64
+	connsCreated  atomic.Int64
+	connsReused   atomic.Int64
+}
+
+// Stats returns a snapshot of the client's runtime counters.
+// It is safe for concurrent use.
+// It sends no request.
+func (c *Client) Stats() ClientStats {
+	return ClientStats{
+		Requests:      c.stats.requests.Load(),
+		Errors:        c.stats.errors.Load(),
+		Retries:       c.stats.retries.Load(),
+		TokenRenewals: c.stats.tokenRenewals.Load(),
+		InFlight:      c.stats.inFlight.Load(),
+		ConnsCreated:  c.stats.connsCreated.Load(),
+		ConnsReused:   c.stats.connsReused.Load(),
+	}
+}
@@ -0,0 +1,85 @@
+/**
+ * Copyright (c) 2023 Sebastian Borchers
+ *
+ * This software is released under the MIT License.
+ * https://opensource.org/licenses/MIT
+ */
+
+package wego
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestSequentialRequestsReuseConnection(t *testing.T) {
+	const n = 60
+
+	var conns atomic.Int64
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case defaultLoginPath:
+			writeLoginResponse(w)
+		case "/api/ok":
+			writeJSON(w, map[string]string{"_id": strings.Repeat("x", 32<<10)})
+		case "/api/notfound":
+			writeErrorStatus(w, http.StatusNotFound)
+		default:
+			writeErrorStatus(w, http.StatusInternalServerError)
+		}
+	}))
+	srv.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			conns.Add(1)
+		}
+	}
+	srv.Start()
+	defer srv.Close()
+
+	c := newTestClient(t, srv, Options{})
+
+	// Successful responses are parsed or, if no result is expected, left unread.
+	requests := []struct {
+		path    string
+		parse   bool
+		wantErr bool
+	}{
+		{path: "ok", parse: true},
+		{path: "ok"},
+		{path: "notfound", wantErr: true},
+		{path: "error", wantErr: true},
+	}
+	for i := 0; i < n; i++ {
+		r := requests[i%len(requests)]
+
+		req, err := c.newAuthenticatedGETRequest(context.Background(), c.endpoint(r.path))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var resp any
+		if r.parse {
+			resp = &struct {
+				ID string `json:"_id"`
+			}{}
+		}
+
+		err = c.doSimpleRequest(req, resp)
+		if (err != nil) != r.wantErr {
+			t.Fatalf("request %d to '%s': unexpected error: %v", i, r.path, err)
+		}
+	}
+
+	// The login may use a connection of its own.
+	if got := conns.Load(); got > 2 {
+		t.Fatalf("expected at most 2 connections for %d sequential requests, got %d", n, got)
+	}
+	if s := c.Stats(); s.ConnsReused < n-1 {
+		t.Fatalf("expected at least %d reused connections, got %d", n-1, s.ConnsReused)
+	}
+}
@@ -0,0 +1,66 @@
+/**
+ * Copyright (c) 2023 Sebastian Borchers
+ *
+ * This software is released under the MIT License.
+ * https://opensource.org/licenses/MIT
+ */
+
+package wego
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"runtime"
+	"strings"
+	"unicode"
+)
+
+// The prefix of the runtime function names of the client's methods,
+// e.g. "github.com/desertbit/wego.(*Client).". It is derived from a method of the client,
+// so that it matches regardless of the path the module is imported with.
+var clientMethodPrefix = strings.TrimSuffix(
+	runtime.FuncForPC(reflect.ValueOf((*Client).endpoint).Pointer()).Name(),
+	"endpoint",
+)
+
+// withTimeout returns a copy of req whose context expires after the timeout configured
+// for the method sending req. If the context of req already has a deadline, req is
+// returned unchanged.
+// The method is looked up in the call stack, which is only done, if OperationTimeouts is set.
+// The returned cancel func must be called once the response has been consumed.
+func (c *Client) withTimeout(req *http.Request) (*http.Request, context.CancelFunc) {
+	if _, ok := req.Context().Deadline(); ok {
+		return req, func() {}
+	}
+
+	timeout := c.opts.Timeout
+	if len(c.opts.OperationTimeouts) > 0 {
+		if t, ok := c.opts.OperationTimeouts[operationName()]; ok {
+			timeout = t
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), timeout)
+	return req.WithContext(ctx), cancel
+}
+
+// operationName returns the name of the nearest exported method of the client
+// in the call stack, or an empty string.
+// Methods of the handles, e.g. BoardClient, resolve to the method of the client they wrap.
+func operationName() string {
+	pcs := make([]uintptr, 32)
+	n := runtime.Callers(3, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+
+	for {
+		f, more := frames.Next()
+		if name, ok := strings.CutPrefix(f.Function, clientMethodPrefix); ok &&
+			!strings.Contains(name, ".") && unicode.IsUpper([]rune(name)[0]) {
+			return name
+		}
+		if !more {
+			return ""
+		}
+	}
+}
@@ -0,0 +1,66 @@
+/**
+ * Copyright (c) 2023 Sebastian Borchers
+ *
+ * This software is released under the MIT License.
+ * https://opensource.org/licenses/MIT
+ */
+
+package wego
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestClientMethodPrefix(t *testing.T) {
+	if !strings.HasSuffix(clientMethodPrefix, ".(*Client).") {
+		t.Fatalf("unexpected client method prefix '%s'", clientMethodPrefix)
+	}
+}
+
+func TestOperationTimeouts(t *testing.T) {
+	const delay = 300 * time.Millisecond
+
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-time.After(delay):
+		}
+		writeJSON(w, GetBoard{Title: "board"})
+	})
+	c := newTestClient(t, srv, Options{
+		OperationTimeouts: map[string]time.Duration{"ExportJSON": 50 * time.Millisecond},
+	})
+	ctx := context.Background()
+
+	exports := map[string]func() error{
+		"Client": func() error {
+			_, err := c.ExportJSON(ctx, "b")
+			return err
+		},
+		"BoardClient": func() error {
+			_, err := c.Board("b").ExportJSON(ctx)
+			return err
+		},
+	}
+	for name, export := range exports {
+		start := time.Now()
+		err := export()
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("%s: expected the operation timeout to expire, got: %v", name, err)
+		} else if d := time.Since(start); d >= delay {
+			t.Fatalf("%s: operation timeout has not been applied, the export took %v", name, d)
+		}
+	}
+
+	// Other methods use the default timeout.
+	_, err := c.GetBoard(ctx, "b")
+	if err != nil {
+		t.Fatalf("expected GetBoard to use the default timeout, got: %v", err)
+	}
+}
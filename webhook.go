@@ -0,0 +1,101 @@
+/**
+ * Copyright (c) 2023 Sebastian Borchers
+ *
+ * This software is released under the MIT License.
+ * https://opensource.org/licenses/MIT
+ */
+
+package wego
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+const (
+	// The header Wekan sends the token of an integration in.
+	webhookTokenHeader = "X-Wekan-Token"
+
+	// The maximum size of a webhook payload that is read.
+	maxWebhookBytes = 1 << 20
+
+	// The prefix of the activity type in the description of a webhook payload.
+	webhookActivityPrefix = "act-"
+)
+
+// ErrInvalidWebhookToken is returned by VerifyWebhook, if the token of a request
+// does not match the integration's token.
+var ErrInvalidWebhookToken = errors.New("invalid webhook token")
+
+// VerifyWebhook verifies that r is an outgoing webhook request of a Wekan integration
+// with the given token (see EditIntegrationOptions.Token) and parses its payload.
+// The token is compared in constant time.
+// This is a helper for servers receiving Wekan's webhooks, it does not require a Client.
+//
+// Returns an error, if secret is empty, since an empty token would match requests without one.
+// Returns ErrInvalidWebhookToken, if the token of the request is missing or does not match.
+func VerifyWebhook(r *http.Request, secret string) (e WebhookEvent, err error) {
+	if secret == "" {
+		err = errors.New("empty webhook secret")
+		return
+	} else if r.Method != http.MethodPost {
+		err = fmt.Errorf("unexpected webhook method '%s'", r.Method)
+		return
+	}
+
+	token := r.Header.Get(webhookTokenHeader)
+	if token == "" || subtle.ConstantTimeCompare([]byte(token), []byte(secret)) != 1 {
+		err = ErrInvalidWebhookToken
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxWebhookBytes))
+	if err != nil {
+		err = fmt.Errorf("failed to read webhook payload: %v", err)
+		return
+	}
+
+	return ParseWebhookEvent(body)
+}
+
+// ParseWebhookEvent parses the payload of an outgoing webhook request of a Wekan integration.
+// Unknown fields are ignored. Use VerifyWebhook to additionally verify the request's token.
+func ParseWebhookEvent(body []byte) (e WebhookEvent, err error) {
+	err = json.Unmarshal(body, &e)
+	if err != nil {
+		err = fmt.Errorf("failed to unmarshal webhook payload: %v", err)
+		return
+	}
+
+	e.ActivityType = ActivityType(strings.TrimPrefix(e.Description, webhookActivityPrefix))
+	return
+}
+
+// WebhookEvent is the payload of an outgoing webhook request of a Wekan integration.
+type WebhookEvent struct {
+	// A human readable description of the event.
+	Text string `json:"text"`
+	// The activity type of the event, prefixed with "act-", e.g. "act-createCard".
+	Description string `json:"description"`
+	// The activity type of the event, parsed from Description.
+	ActivityType ActivityType `json:"-"`
+	// The username of the user that caused the event.
+	User string `json:"user"`
+	// The title of the card.
+	Card       string `json:"card"`
+	CardID     string `json:"cardId"`
+	ListID     string `json:"listId"`
+	OldListID  string `json:"oldListId"`
+	SwimlaneID string `json:"swimlaneId"`
+	BoardID    string `json:"boardId"`
+	CommentID  string `json:"commentId"`
+	// The text of the comment of comment events.
+	Comment string `json:"comment"`
+	// The url of the card in the Wekan UI.
+	URL string `json:"url"`
+}
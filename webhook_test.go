@@ -0,0 +1,69 @@
+/**
+ * Copyright (c) 2023 Sebastian Borchers
+ *
+ * This software is released under the MIT License.
+ * https://opensource.org/licenses/MIT
+ */
+
+package wego
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestVerifyWebhook(t *testing.T) {
+	const (
+		secret  = "secret"
+		payload = `{"text":"card created","description":"act-createCard","cardId":"c"}`
+	)
+
+	newRequest := func(token string) *http.Request {
+		r := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(payload))
+		if token != "" {
+			r.Header.Set(webhookTokenHeader, token)
+		}
+		return r
+	}
+
+	e, err := VerifyWebhook(newRequest(secret), secret)
+	if err != nil {
+		t.Fatal(err)
+	} else if e.CardID != "c" || e.ActivityType != ActivityTypeCreateCard {
+		t.Fatalf("unexpected event: %+v", e)
+	}
+
+	tests := []struct {
+		name      string
+		token     string
+		secret    string
+		wantToken bool
+	}{
+		{name: "empty secret without token", secret: ""},
+		{name: "empty secret with token", token: secret, secret: ""},
+		{name: "missing token", secret: secret, wantToken: true},
+		{name: "wrong token", token: "other", secret: secret, wantToken: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := VerifyWebhook(newRequest(tt.token), tt.secret)
+			if err == nil {
+				t.Fatal("expected an error")
+			} else if errors.Is(err, ErrInvalidWebhookToken) != tt.wantToken {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+
+	// An explicitly empty token header is rejected as well.
+	r := newRequest("")
+	r.Header.Set(webhookTokenHeader, "")
+	_, err = VerifyWebhook(r, secret)
+	if !errors.Is(err, ErrInvalidWebhookToken) {
+		t.Fatalf("expected ErrInvalidWebhookToken, got: %v", err)
+	}
+}
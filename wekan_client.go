@@ -0,0 +1,184 @@
+/**
+ * Copyright (c) 2023 Sebastian Borchers
+ *
+ * This software is released under the MIT License.
+ * https://opensource.org/licenses/MIT
+ */
+
+package wego
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+)
+
+// WekanClient is the set of methods implemented by *Client.
+// Code depending on a client can accept a WekanClient instead, so that it can be tested
+// with a fake implementation.
+// New methods of Client are added to it, therefore implementations should embed
+// WekanClient or *Client to stay compatible.
+type WekanClient interface {
+	// Client
+	Close() error
+	NewImpersonatedClient(ctx context.Context, userID string) (*Client, error)
+	WaitReady(ctx context.Context) error
+	TokenExpires() time.Time
+	TokenTTL() time.Duration
+	HTTPClient() *http.Client
+
+	// Activities
+	GetBoardActivities(ctx context.Context, boardID string, opts ActivitiesOptions) ([]Activity, error)
+	GetCardActivities(ctx context.Context, boardID, cardID string, opts ActivitiesOptions) ([]Activity, error)
+
+	// Attachments
+	DownloadAttachment(ctx context.Context, boardID, attachmentID string, w io.Writer) error
+	DownloadBoardAttachments(ctx context.Context, boardID string, w io.Writer) error
+
+	// Boards
+	GetPublicBoards(ctx context.Context) ([]GetPublicBoard, error)
+	NewBoard(ctx context.Context, request NewBoardRequest) (NewBoardResponse, error)
+	NewBoardFromTemplate(ctx context.Context, templateBoardID string, request NewBoardRequest) (NewBoardResponse, error)
+	GetBoard(ctx context.Context, boardID string) (GetBoard, error)
+	GetBoardSummary(ctx context.Context, boardID string) (BoardSummary, error)
+	GetBoardFull(ctx context.Context, boardID string, opts BoardFullOptions) (BoardFull, error)
+	GetBoardStats(ctx context.Context, boardID string) (BoardStats, error)
+	BoardExists(ctx context.Context, boardID string) (bool, error)
+	DeleteBoard(ctx context.Context, boardID string) error
+	GetBoardAttachments(ctx context.Context, boardID string) ([]BoardAttachment, error)
+	GetBoardAttachmentsFiltered(ctx context.Context, boardID string, filter BoardAttachmentFilter) ([]BoardAttachment, error)
+	ExportJSON(ctx context.Context, boardID string) (json.RawMessage, error)
+	ExportJSONTo(ctx context.Context, boardID string, w io.Writer) error
+	AddBoardLabel(ctx context.Context, boardID, name, color string) (string, error)
+	EnsureBoardLabel(ctx context.Context, boardID, name, color string) (BoardLabel, error)
+	GetBoardLabels(ctx context.Context, boardID string) ([]BoardLabel, error)
+	GetBoardLabelByName(ctx context.Context, boardID, name string) (BoardLabel, error)
+	SetBoardMemberPermission(ctx context.Context, boardID, memberID string, opts SetBoardMemberPermissionOptions) error
+	GetBoardsCount(ctx context.Context) (GetBoardsCountResponse, error)
+	GetBoardsModifiedSince(ctx context.Context, userID string, since time.Time) ([]GetBoardFromUser, error)
+	GetUserBoards(ctx context.Context, userID string) ([]GetBoardFromUser, error)
+	GetBoardsFromUser(ctx context.Context, userID string) ([]GetBoardFromUser, error)
+
+	// Card comments
+	GetAllComments(ctx context.Context, boardID, cardID string) ([]GetAllComment, error)
+	NewComment(ctx context.Context, boardID, cardID string, data NewCommentRequest) (NewCommentResponse, error)
+	GetComment(ctx context.Context, boardID, cardID, commentID string) (GetComment, error)
+	DeleteComment(ctx context.Context, boardID, cardID, commentID string) error
+
+	// Cards
+	GetCardsByCustomField(ctx context.Context, boardID, customField, customFieldValue string) ([]GetCardByCustomField, error)
+	GetCardsByCustomFieldFull(ctx context.Context, boardID, customField, customFieldValue string) ([]GetCard, error)
+	GetAllCards(ctx context.Context, boardID, listID string) ([]GetAllCard, error)
+	GetAllCardsSorted(ctx context.Context, boardID, listID string, opts CardSortOptions) ([]GetCard, error)
+	GetArchivedCards(ctx context.Context, boardID, listID string) ([]GetCard, error)
+	GetCardsDueBefore(ctx context.Context, boardID string, before time.Time) ([]GetCard, error)
+	NewCard(ctx context.Context, boardID, listID string, request NewCardRequest) (NewCardResponse, error)
+	QuickAddCard(ctx context.Context, boardID, title string) (NewCardResponse, error)
+	GetCardTemplates(ctx context.Context, boardID string) ([]GetCard, error)
+	NewCardFromTemplate(ctx context.Context, boardID, listID, templateCardID string, overrides EditCardOptions) (NewCardResponse, error)
+	GetCard(ctx context.Context, boardID, listID, cardID string) (GetCard, error)
+	ClearCardLabels(ctx context.Context, boardID, listID, cardID string) error
+	WaitForCard(ctx context.Context, boardID, listID, cardID string, cond func(GetCard) bool, interval time.Duration) (GetCard, error)
+	EditCardWith(ctx context.Context, boardID, listID, cardID string, opts ...EditCardOption) (EditCardResponse, error)
+	GetCardWithResolvedMembers(ctx context.Context, boardID, listID, cardID string) (CardWithMembers, error)
+	GetCardByID(ctx context.Context, boardID, cardID string) (GetCard, error)
+	CardExists(ctx context.Context, boardID, listID, cardID string) (bool, error)
+	EditCard(ctx context.Context, boardID, listID, cardID string, opts EditCardOptions) (EditCardResponse, error)
+	MoveCards(ctx context.Context, boardID string, cardIDs []string, destListID, destSwimlaneID string) (int, error)
+	MoveCardToTop(ctx context.Context, boardID, listID, cardID string) error
+	MoveCardToBottom(ctx context.Context, boardID, listID, cardID string) error
+	RebalanceListSort(ctx context.Context, boardID, listID string) error
+	SetCardDates(ctx context.Context, boardID, listID, cardID string, dates CardDates) error
+	DeleteCard(ctx context.Context, boardID, cardID string) error
+	GetSwimlaneCards(ctx context.Context, boardID, swimlaneID string) ([]GetSwimlaneCard, error)
+	IterateSwimlaneCards(ctx context.Context, boardID, swimlaneID string, fn func(card GetSwimlaneCard) bool) error
+
+	// Checklist items
+	NewChecklistItem(ctx context.Context, boardID, cardID, checklistID, title string) (NewChecklistItemResponse, error)
+	AddChecklistItems(ctx context.Context, boardID, cardID, checklistID string, titles []string) ([]string, error)
+	GetChecklistItem(ctx context.Context, boardID, cardID, checklistID, itemID string) (GetChecklistItem, error)
+	EditChecklistItem(ctx context.Context, boardID, cardID, checklistID, itemID string, data EditChecklistItemRequest) error
+	DeleteChecklistItem(ctx context.Context, boardID, cardID, checklistID, itemID string) error
+
+	// Checklists
+	GetAllChecklists(ctx context.Context, boardID, cardID string) ([]GetAllChecklist, error)
+	NewChecklist(ctx context.Context, boardID, cardID string, data NewChecklistRequest) (NewChecklistResponse, error)
+	NewChecklistWithItems(ctx context.Context, boardID, cardID string, data NewChecklistRequest) (GetChecklist, error)
+	GetChecklist(ctx context.Context, boardID, cardID, checklistID string) (GetChecklist, error)
+	DeleteChecklist(ctx context.Context, boardID, cardID, checklistID string) error
+
+	// Custom fields
+	GetAllCustomFields(ctx context.Context, boardID string) ([]GetAllCustomField, error)
+	NewCustomField(ctx context.Context, boardID string, data NewCustomFieldRequest) (NewCustomFieldResponse, error)
+	GetCustomField(ctx context.Context, boardID string) ([]GetCustomField, error)
+	GetCustomFieldDetails(ctx context.Context, boardID, customFieldID string) (CustomFieldDetail, error)
+	EditCustomField(ctx context.Context, boardID string, data EditCustomFieldRequest) (EditCustomFieldResponse, error)
+	DeleteCustomField(ctx context.Context, boardID, customFieldID string) error
+	AddCustomFieldDropdownItems(ctx context.Context, boardID, customFieldID string, items []string) error
+	AddCustomFieldDropdownItemsWithIDs(ctx context.Context, boardID, customFieldID string, items []string) ([]DropdownItem, error)
+	EditCustomFieldDropdownItems(ctx context.Context, boardID, customFieldID, dropdownItem, name string) error
+	DeleteCustomFieldDropdownItem(ctx context.Context, boardID, customFieldID, dropdownItem string) error
+	SetCardCustomFieldValue(ctx context.Context, boardID, listID, cardID, customFieldID string, value any) error
+	SetCardCustomFieldByName(ctx context.Context, boardID, listID, cardID, fieldName string, value any) error
+	GetCardCustomFieldValue(ctx context.Context, boardID, listID, cardID, customFieldID string) (any, bool, error)
+
+	// Handles
+	Board(boardID string) *BoardClient
+
+	// Integrations
+	GetAllIntegrations(ctx context.Context, boardID string) ([]Integration, error)
+	NewIntegration(ctx context.Context, boardID, url string) (NewIntegrationResponse, error)
+	NewIntegrationWithOptions(ctx context.Context, boardID string, opts EditIntegrationOptions) (NewIntegrationResponse, error)
+	GetIntegration(ctx context.Context, boardID, integrationID string) (Integration, error)
+	EditIntegration(ctx context.Context, boardID, integrationID string, data EditIntegrationOptions) error
+	DeleteIntegration(ctx context.Context, boardID, integrationID string) error
+	DeleteIntegrationActivities(ctx context.Context, boardID, integrationID string) error
+	NewIntegrationActivities(ctx context.Context, boardID, integrationID string, activities []ActivityType) (Integration, error)
+
+	// Lists
+	GetAllLists(ctx context.Context, boardID string) ([]GetAllList, error)
+	GetAllListsSorted(ctx context.Context, boardID string) ([]GetAllList, error)
+	GetAllListsMulti(ctx context.Context, boardIDs []string) (map[string][]GetAllList, error)
+	CanSetWipLimit(ctx context.Context, boardID, listID string, limit int) (bool, error)
+	NewList(ctx context.Context, boardID, title string) (NewListResponse, error)
+	GetList(ctx context.Context, boardID, listID string) (GetList, error)
+	ListExists(ctx context.Context, boardID, listID string) (bool, error)
+	DeleteList(ctx context.Context, boardID, listID string) error
+
+	// Login
+	Login(ctx context.Context, username, password string) (LoginResponse, error)
+	Register(ctx context.Context, username, password, email string) (LoginResponse, error)
+
+	// Debugging
+	GetRaw(ctx context.Context, path string) (json.RawMessage, error)
+
+	// Swimlanes
+	GetAllSwimlanes(ctx context.Context, boardID string) ([]GetAllSwimlane, error)
+	GetArchivedSwimlanes(ctx context.Context, boardID string) ([]GetSwimlane, error)
+	NewSwimlane(ctx context.Context, boardID, title string) (NewSwimlaneResponse, error)
+	GetSwimlane(ctx context.Context, boardID, swimlaneID string) (GetSwimlane, error)
+	DeleteSwimlane(ctx context.Context, boardID, swimlaneID string) error
+
+	// Users
+	GetCurrentUserID() string
+	AddBoardMember(ctx context.Context, boardID, userID string, data AddBoardMemberRequest) error
+	RemoveBoardMember(ctx context.Context, boardID, userID string) error
+	CreateUserToken(ctx context.Context, userID string) (CreateUserTokenResponse, error)
+	GetCurrentUser(ctx context.Context) (User, error)
+	GetAllUsers(ctx context.Context) ([]GetAllUser, error)
+	NewUser(ctx context.Context, data NewUserRequest) (NewUserResponse, error)
+	GetUser(ctx context.Context, userID string) (User, error)
+	GetUserNotifications(ctx context.Context, userID string) ([]ProfileNotification, error)
+	EditUser(ctx context.Context, userID, action string) error
+	DeleteUser(ctx context.Context, userID string) error
+
+	// Watching
+	WatchBoard(ctx context.Context, boardID string, interval time.Duration) (<-chan BoardChange, error)
+
+	// Stats
+	Stats() ClientStats
+}
+
+var _ WekanClient = (*Client)(nil)